@@ -0,0 +1,25 @@
+// Command fixtures generates a reproducible corpus of sample student reports
+// for integration tests. It is built separately from the service.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"student-report-service/internal/fixtures"
+)
+
+func main() {
+	seed := flag.Int64("seed", 1, "seed the fake students are generated from")
+	count := flag.Int("count", 10, "number of students to generate")
+	out := flag.String("out", "./fixtures", "directory the reports are written to")
+	flag.Parse()
+
+	paths, err := fixtures.Generate(*out, *seed, *count)
+	if err != nil {
+		log.Fatalf("Failed to generate fixtures: %v", err)
+	}
+
+	fmt.Printf("Generated %d reports for %d students in %s\n", len(paths), *count, *out)
+}
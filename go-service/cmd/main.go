@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -13,6 +15,7 @@ import (
 	"student-report-service/internal/config"
 	"student-report-service/internal/handlers"
 	"student-report-service/internal/pdf"
+	"student-report-service/internal/redact"
 	"student-report-service/internal/service"
 
 	"github.com/gorilla/mux"
@@ -21,14 +24,37 @@ import (
 )
 
 func main() {
+	printConfig := flag.Bool("print-config", false, "print the effective configuration as JSON, with secrets redacted, and exit")
+	flag.Parse()
+
 	// Load configuration
 	cfg := config.Load()
+	if *printConfig {
+		printEffectiveConfig(cfg)
+		return
+	}
 	if err := cfg.Validate(); err != nil {
 		log.Fatalf("Invalid configuration: %v", err)
 	}
+	if err := cfg.Report.LoadProfiles(); err != nil {
+		log.Fatalf("Invalid report profiles: %v", err)
+	}
+	if err := cfg.Report.LoadCoverLetter(); err != nil {
+		log.Fatalf("Invalid cover letter: %v", err)
+	}
+	if err := cfg.Report.LoadWatermarkRules(); err != nil {
+		log.Fatalf("Invalid watermark rules: %v", err)
+	}
+	if err := cfg.Report.LoadRequiredOptions(); err != nil {
+		log.Fatalf("Invalid required report options: %v", err)
+	}
 
 	// Setup logger
 	logger := setupLogger(cfg.Logging)
+	redactor := redact.New(cfg.Logging.RedactPII)
+	if redactor.Enabled() {
+		logger.AddHook(redactor.Hook())
+	}
 	logger.Info("Starting Student Report Service")
 
 	// Initialize components
@@ -42,9 +68,36 @@ func main() {
 	if err != nil {
 		logger.WithError(err).Fatal("Failed to initialize PDF generator")
 	}
+	pdfGenerator.SetLogger(logger)
+	if cfg.Report.Index {
+		count, err := pdfGenerator.RebuildIndex()
+		if err != nil {
+			logger.WithError(err).Warn("Failed to build report index; lookups will scan the output directory")
+		} else {
+			logger.WithField("reports", count).Info("Built report index")
+		}
+	}
 
 	reportService := service.NewReportServiceWithConcreteTypes(nodeClient, pdfGenerator, cfg)
+
+	if cfg.SafeMode {
+		logger.Warn("Safe mode is enabled - external integrations are disabled")
+	}
+	if cfg.Photo.BaseURL != "" && !cfg.SafeMode {
+		photoClient, err := client.NewPhotoClient(&cfg.Photo, logger)
+		if err != nil {
+			logger.WithError(err).Fatal("Failed to initialize photo client")
+		}
+		reportService.SetPhotoClient(photoClient)
+	}
 	reportHandler := handlers.NewReportHandler(reportService)
+	reportHandler.SetRedactor(redactor)
+	reportHandler.SetStreamWriteTimeout(cfg.Server.WriteTimeout)
+
+	// Pre-generate reports for the warm list in the background
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reportService.StartPregeneration(ctx, logger)
 
 	// Setup router
 	router := setupRouter(reportHandler, logger)
@@ -78,6 +131,33 @@ func main() {
 	setupGracefulShutdown(server, logger)
 }
 
+// printEffectiveConfig writes the resolved configuration to stdout with
+// secrets redacted. Problems that would stop the service from starting are
+// reported on stderr instead of preventing the output.
+func printEffectiveConfig(cfg *config.Config) {
+	if err := cfg.Report.LoadProfiles(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid report profiles: %v\n", err)
+	}
+	if err := cfg.Report.LoadCoverLetter(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid cover letter: %v\n", err)
+	}
+	if err := cfg.Report.LoadWatermarkRules(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid watermark rules: %v\n", err)
+	}
+	if err := cfg.Report.LoadRequiredOptions(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid required report options: %v\n", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
+	}
+
+	data, err := cfg.RedactedJSON()
+	if err != nil {
+		log.Fatalf("Failed to encode configuration: %v", err)
+	}
+	fmt.Println(string(data))
+}
+
 func setupLogger(cfg config.LoggingConfig) *logrus.Logger {
 	logger := logrus.New()
 
@@ -118,9 +198,15 @@ func setupRouter(handler *handlers.ReportHandler, logger *logrus.Logger) *mux.Ro
 
 	// Student listing endpoint
 	api.HandleFunc("/students", handler.GetStudents).Methods("GET")
+	api.HandleFunc("/students/export", handler.ExportStudentsCSV).Methods("GET")
 
 	// Report generation
 	api.HandleFunc("/reports/student/{id:[0-9]+}", handler.GenerateReport).Methods("POST")
+	api.HandleFunc("/reports/student", handler.GenerateReportFromStudent).Methods("POST")
+	api.HandleFunc("/reports/household", handler.GenerateHouseholdReport).Methods("POST")
+	api.HandleFunc("/reports/{reportID:RPT-[A-Za-z0-9-]+}/text", handler.GetReportText).Methods("GET")
+	api.HandleFunc("/reports/{reportID:RPT-[A-Za-z0-9-]+}/verify", handler.VerifyReport).Methods("GET")
+	api.HandleFunc("/reports/index/rebuild", handler.RebuildReportIndex).Methods("POST")
 
 	// Cleanup endpoint
 	api.HandleFunc("/reports/cleanup", handler.CleanupReports).Methods("POST")
@@ -202,3 +288,15 @@ func (w *responseWriterWrapper) WriteHeader(statusCode int) {
 	w.statusCode = statusCode
 	w.ResponseWriter.WriteHeader(statusCode)
 }
+
+// Flush sends buffered data to the client, so streamed responses aren't held back
+func (w *responseWriterWrapper) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying ResponseWriter
+func (w *responseWriterWrapper) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -1,8 +1,12 @@
 package client
 
 import (
+	"bytes"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"sync"
 	"time"
@@ -16,10 +20,11 @@ import (
 
 // NodeJSClient handles communication with the Node.js backend API
 type NodeJSClient struct {
-	client  *resty.Client
-	config  *config.NodeJSConfig
-	logger  *logrus.Logger
-	baseURL string
+	client    *resty.Client
+	config    *config.NodeJSConfig
+	logger    *logrus.Logger
+	baseURL   string
+	tlsConfig *tls.Config
 
 	// Authentication state - manual token management
 	accessToken  string
@@ -39,6 +44,11 @@ func (e *ClientError) Error() string {
 	return fmt.Sprintf("API Error %d: %s", e.StatusCode, e.Message)
 }
 
+// ErrIncompleteResponse is returned when the Node.js API answers with a body
+// that was truncated or is missing required fields. The client has already
+// retried the request when it is returned, so callers should not retry it again.
+var ErrIncompleteResponse = errors.New("incomplete response from Node.js API")
+
 // LoginRequest represents the login request payload
 type LoginRequest struct {
 	Username string `json:"username"`
@@ -67,8 +77,14 @@ func NewNodeJSClient(cfg *config.NodeJSConfig, logger *logrus.Logger) (*NodeJSCl
 		logger.Warn("Service credentials not configured - authentication may fail")
 	}
 
+	tlsConfig, err := cfg.TLSClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS configuration: %w", err)
+	}
+
 	// Create resty client with retry configuration
 	client := resty.New().
+		SetTLSClientConfig(tlsConfig.Clone()).
 		SetBaseURL(cfg.BaseURL).
 		SetTimeout(cfg.Timeout).
 		SetRetryCount(cfg.RetryAttempts).
@@ -77,17 +93,60 @@ func NewNodeJSClient(cfg *config.NodeJSConfig, logger *logrus.Logger) (*NodeJSCl
 		SetHeader("Content-Type", "application/json").
 		SetHeader("Accept", "application/json")
 
-	// Enable debug logging if logger level is debug
+	// Enable debug logging if logger level is debug, routed through the
+	// service logger so its hooks apply to request dumps
 	if logger.Level == logrus.DebugLevel {
-		client.SetDebug(true)
+		client.SetLogger(logger).SetDebug(true)
+	}
+
+	c := &NodeJSClient{
+		client:    client,
+		config:    cfg,
+		logger:    logger,
+		baseURL:   cfg.BaseURL,
+		tlsConfig: tlsConfig,
+	}
+	client.OnAfterResponse(c.logRequestDuration)
+	client.OnError(c.logRequestError)
+
+	return c, nil
+}
+
+// logRequestDuration logs requests slower than the configured threshold at
+// warn level; other requests are only logged at debug level
+func (c *NodeJSClient) logRequestDuration(_ *resty.Client, resp *resty.Response) error {
+	fields := logrus.Fields{
+		"method":      resp.Request.Method,
+		"url":         resp.Request.URL,
+		"status_code": resp.StatusCode(),
+		"duration":    resp.Time().String(),
+	}
+
+	if threshold := c.config.SlowRequestThreshold; threshold > 0 && resp.Time() > threshold {
+		fields["threshold"] = threshold.String()
+		c.logger.WithFields(fields).Warn("Slow request to Node.js API")
+		return nil
+	}
+
+	c.logger.WithFields(fields).Debug("Request to Node.js API completed")
+	return nil
+}
+
+// logRequestError logs requests that got no response, such as timeouts and
+// transport errors, which never reach logRequestDuration
+func (c *NodeJSClient) logRequestError(req *resty.Request, err error) {
+	var respErr *resty.ResponseError
+	if errors.As(err, &respErr) && respErr.Response.RawResponse != nil {
+		// A response arrived and was already logged by logRequestDuration
+		return
 	}
 
-	return &NodeJSClient{
-		client:  client,
-		config:  cfg,
-		logger:  logger,
-		baseURL: cfg.BaseURL,
-	}, nil
+	c.logger.WithFields(logrus.Fields{
+		"method":   req.Method,
+		"url":      req.URL,
+		"duration": time.Since(req.Time).String(),
+		"error":    err.Error(),
+	}).Warn("Request to Node.js API failed")
 }
 
 // authenticate performs login and stores authentication tokens
@@ -212,19 +271,10 @@ func (c *NodeJSClient) makeAuthenticatedRequest(method, endpoint string) (*resty
 		return nil, fmt.Errorf("authentication failed: %w", err)
 	}
 
-	c.authMutex.RLock()
-	accessToken := c.accessToken
-	refreshToken := c.refreshToken
-	csrfToken := c.csrfToken
-	c.authMutex.RUnlock()
-
-	var errorResp models.ErrorResponse
-
 	// Use manual cookie headers for reliable authentication - don't set result here
-	resp, err := c.client.R().
+	var errorResp models.ErrorResponse
+	resp, err := c.newAuthenticatedRequest().
 		SetError(&errorResp).
-		SetHeader("X-CSRF-TOKEN", csrfToken).
-		SetHeader("Cookie", fmt.Sprintf("accessToken=%s; refreshToken=%s", accessToken, refreshToken)).
 		Execute(method, endpoint)
 
 	// If we get a 401, try to re-authenticate once
@@ -235,28 +285,82 @@ func (c *NodeJSClient) makeAuthenticatedRequest(method, endpoint string) (*resty
 		}
 
 		// Retry the request with new tokens
-		c.authMutex.RLock()
-		newAccessToken := c.accessToken
-		newRefreshToken := c.refreshToken
-		newCsrfToken := c.csrfToken
-		c.authMutex.RUnlock()
-
-		resp, err = c.client.R().
+		resp, err = c.newAuthenticatedRequest().
 			SetError(&errorResp).
-			SetHeader("X-CSRF-TOKEN", newCsrfToken).
-			SetHeader("Cookie", fmt.Sprintf("accessToken=%s; refreshToken=%s", newAccessToken, newRefreshToken)).
 			Execute(method, endpoint)
 	}
 
 	return resp, err
 }
 
+// newAuthenticatedRequest creates a request carrying the current session tokens
+func (c *NodeJSClient) newAuthenticatedRequest() *resty.Request {
+	c.authMutex.RLock()
+	accessToken := c.accessToken
+	refreshToken := c.refreshToken
+	csrfToken := c.csrfToken
+	c.authMutex.RUnlock()
+
+	return c.client.R().
+		SetHeader("X-CSRF-TOKEN", csrfToken).
+		SetHeader("Cookie", fmt.Sprintf("accessToken=%s; refreshToken=%s", accessToken, refreshToken))
+}
+
+// decodeComplete decodes a JSON body, rejecting bodies that were cut off
+// mid-stream or carry trailing data after the top-level value
+func decodeComplete(body []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if err := decoder.Decode(v); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.As(err, &syntaxErr) {
+			return fmt.Errorf("%w: %v", ErrIncompleteResponse, err)
+		}
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("%w: unexpected data after JSON body", ErrIncompleteResponse)
+	}
+
+	return nil
+}
+
+// retryIncomplete re-runs fn while it fails with ErrIncompleteResponse,
+// up to the configured number of retry attempts
+func (c *NodeJSClient) retryIncomplete(endpoint string, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || !errors.Is(err, ErrIncompleteResponse) || attempt >= c.config.RetryAttempts {
+			return err
+		}
+
+		c.logger.WithFields(logrus.Fields{
+			"endpoint": endpoint,
+			"attempt":  attempt + 1,
+			"error":    err.Error(),
+		}).Warn("Incomplete response from Node.js API, retrying")
+
+		time.Sleep(c.config.RetryDelay)
+	}
+}
+
 // GetStudentByID retrieves a student by ID from the Node.js API with authentication
 func (c *NodeJSClient) GetStudentByID(studentID int) (*models.Student, error) {
 	if studentID <= 0 {
 		return nil, fmt.Errorf("invalid student ID: %d", studentID)
 	}
 
+	var student *models.Student
+	err := c.retryIncomplete(fmt.Sprintf("/students/%d", studentID), func() error {
+		var err error
+		student, err = c.fetchStudentByID(studentID)
+		return err
+	})
+	return student, err
+}
+
+// fetchStudentByID performs a single GetStudentByID request
+func (c *NodeJSClient) fetchStudentByID(studentID int) (*models.Student, error) {
 	endpoint := fmt.Sprintf("/students/%d", studentID)
 
 	c.logger.WithFields(logrus.Fields{
@@ -294,8 +398,8 @@ func (c *NodeJSClient) GetStudentByID(studentID int) (*models.Student, error) {
 	}
 
 	var apiResp models.APIResponse
-	if err := json.Unmarshal(resp.Body(), &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	if err := decodeComplete(resp.Body(), &apiResp); err != nil {
+		return nil, err
 	}
 
 	if !apiResp.Success {
@@ -306,25 +410,28 @@ func (c *NodeJSClient) GetStudentByID(studentID int) (*models.Student, error) {
 		}
 	}
 
+	// A body that decodes cleanly can still be a partial student
+	if apiResp.Data.ID == 0 || apiResp.Data.Name == "" {
+		return nil, fmt.Errorf("%w: student is missing required fields (id, name)", ErrIncompleteResponse)
+	}
+
 	return &apiResp.Data, nil
 }
 
 // GetAllStudents retrieves all students from the Node.js API with optional filtering
 func (c *NodeJSClient) GetAllStudents(filters map[string]string) ([]models.StudentListItem, error) {
-	endpoint := "/students"
+	var students []models.StudentListItem
+	err := c.retryIncomplete("/students", func() error {
+		var err error
+		students, err = c.fetchAllStudents(filters)
+		return err
+	})
+	return students, err
+}
 
-	// Build query parameters
-	if len(filters) > 0 {
-		queryParams := make([]string, 0, len(filters))
-		for key, value := range filters {
-			if value != "" {
-				queryParams = append(queryParams, fmt.Sprintf("%s=%s", key, value))
-			}
-		}
-		if len(queryParams) > 0 {
-			endpoint += "?" + strings.Join(queryParams, "&")
-		}
-	}
+// fetchAllStudents performs a single GetAllStudents request
+func (c *NodeJSClient) fetchAllStudents(filters map[string]string) ([]models.StudentListItem, error) {
+	endpoint := studentsEndpoint(filters)
 
 	c.logger.WithFields(logrus.Fields{
 		"endpoint": endpoint,
@@ -344,25 +451,12 @@ func (c *NodeJSClient) GetAllStudents(filters map[string]string) ([]models.Stude
 
 	// Check for HTTP errors
 	if resp.IsError() {
-		var errorResp models.ErrorResponse
-		if err := json.Unmarshal(resp.Body(), &errorResp); err == nil && errorResp.Message != "" {
-			return nil, &ClientError{
-				StatusCode: resp.StatusCode(),
-				Message:    errorResp.Message,
-				Details:    errorResp.Error,
-			}
-		}
-
-		return nil, &ClientError{
-			StatusCode: resp.StatusCode(),
-			Message:    resp.Status(),
-			Details:    string(resp.Body()),
-		}
+		return nil, responseError(resp, resp.Body())
 	}
 
 	var apiResp models.StudentListResponse
-	if err := json.Unmarshal(resp.Body(), &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	if err := decodeComplete(resp.Body(), &apiResp); err != nil {
+		return nil, err
 	}
 
 	if !apiResp.Success {
@@ -376,11 +470,50 @@ func (c *NodeJSClient) GetAllStudents(filters map[string]string) ([]models.Stude
 	return apiResp.Data, nil
 }
 
+// studentsEndpoint builds the student list endpoint with its filter query parameters
+func studentsEndpoint(filters map[string]string) string {
+	endpoint := "/students"
+
+	// Build query parameters
+	if len(filters) > 0 {
+		queryParams := make([]string, 0, len(filters))
+		for key, value := range filters {
+			if value != "" {
+				queryParams = append(queryParams, fmt.Sprintf("%s=%s", key, value))
+			}
+		}
+		if len(queryParams) > 0 {
+			endpoint += "?" + strings.Join(queryParams, "&")
+		}
+	}
+
+	return endpoint
+}
+
+// responseError converts an HTTP error response into a ClientError
+func responseError(resp *resty.Response, body []byte) error {
+	var errorResp models.ErrorResponse
+	if err := json.Unmarshal(body, &errorResp); err == nil && errorResp.Message != "" {
+		return &ClientError{
+			StatusCode: resp.StatusCode(),
+			Message:    errorResp.Message,
+			Details:    errorResp.Error,
+		}
+	}
+
+	return &ClientError{
+		StatusCode: resp.StatusCode(),
+		Message:    resp.Status(),
+		Details:    string(body),
+	}
+}
+
 // HealthCheck performs a health check against the Node.js API
 func (c *NodeJSClient) HealthCheck() error {
 	// For health check, we'll use a simple request to the base API URL
 	// without authentication to avoid circular dependencies
 	healthClient := resty.New().
+		SetTLSClientConfig(c.tlsConfig.Clone()).
 		SetBaseURL(c.baseURL).
 		SetTimeout(5 * time.Second)
 
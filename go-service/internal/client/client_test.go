@@ -0,0 +1,361 @@
+package client
+
+import (
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"student-report-service/internal/config"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer starts a fake Node.js API over plain HTTP
+func newTestServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(fakeAPI(handler))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// fakeAPI accepts the login flow and delegates other requests to handler
+func fakeAPI(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/login" {
+			http.SetCookie(w, &http.Cookie{Name: "accessToken", Value: "access"})
+			http.SetCookie(w, &http.Cookie{Name: "refreshToken", Value: "refresh"})
+			http.SetCookie(w, &http.Cookie{Name: "csrfToken", Value: "csrf"})
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"id":1,"name":"Admin","email":"admin@example.com","role":"admin"}`)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func newTestClient(t *testing.T, baseURL string) *NodeJSClient {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	c, err := NewNodeJSClient(&config.NodeJSConfig{
+		BaseURL:         baseURL,
+		Timeout:         5 * time.Second,
+		RetryAttempts:   2,
+		RetryDelay:      time.Millisecond,
+		ServiceUsername: "admin@example.com",
+		ServicePassword: "secret",
+	}, logger)
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestNodeJSClient_GetStudentByID_IncompleteResponse(t *testing.T) {
+	tests := []struct {
+		name          string
+		bodies        []string
+		expectedError bool
+		expectedCalls int32
+	}{
+		{
+			name:          "Complete response",
+			bodies:        []string{`{"success":true,"data":{"id":1,"name":"John Doe"},"message":"ok"}`},
+			expectedCalls: 1,
+		},
+		{
+			name: "Truncated body is retried",
+			bodies: []string{
+				`{"success":true,"data":{"id":1,"name":"Jo`,
+				`{"success":true,"data":{"id":1,"name":"John Doe"},"message":"ok"}`,
+			},
+			expectedCalls: 2,
+		},
+		{
+			name: "Missing required fields is retried until attempts run out",
+			bodies: []string{
+				`{"success":true,"data":{"id":1}}`,
+				`{"success":true,"data":{"id":1}}`,
+				`{"success":true,"data":{"id":1}}`,
+			},
+			expectedError: true,
+			expectedCalls: 3,
+		},
+		{
+			name: "Trailing data after body",
+			bodies: []string{
+				`{"success":true,"data":{"id":1,"name":"John Doe"}}{"success"`,
+				`{"success":true,"data":{"id":1,"name":"John Doe"}}`,
+			},
+			expectedCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&calls, 1)
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, tt.bodies[int(n)-1])
+			})
+
+			c := newTestClient(t, server.URL)
+			student, err := c.GetStudentByID(1)
+
+			if tt.expectedError {
+				assert.ErrorIs(t, err, ErrIncompleteResponse)
+				assert.Nil(t, student)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "John Doe", student.Name)
+			}
+			assert.Equal(t, tt.expectedCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestNodeJSClient_GetAllStudents_TruncatedResponse(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"success":true,"data":[{"id":1,"name":"John"},{"id":2,`)
+	})
+
+	c := newTestClient(t, server.URL)
+	students, err := c.GetAllStudents(nil)
+
+	assert.ErrorIs(t, err, ErrIncompleteResponse)
+	assert.Nil(t, students)
+}
+
+func TestNodeJSClient_SlowRequestLog(t *testing.T) {
+	tests := []struct {
+		name         string
+		delay        time.Duration
+		threshold    time.Duration
+		expectedWarn bool
+	}{
+		{
+			name:         "Slow request is logged",
+			delay:        50 * time.Millisecond,
+			threshold:    10 * time.Millisecond,
+			expectedWarn: true,
+		},
+		{
+			name:      "Fast request stays quiet",
+			threshold: time.Second,
+		},
+		{
+			name:      "Zero threshold disables the log",
+			delay:     50 * time.Millisecond,
+			threshold: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(tt.delay)
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, `{"success":true,"data":{"id":1,"name":"John Doe"}}`)
+			})
+
+			logger, hook := test.NewNullLogger()
+			c, err := NewNodeJSClient(&config.NodeJSConfig{
+				BaseURL:              server.URL,
+				Timeout:              5 * time.Second,
+				ServiceUsername:      "admin@example.com",
+				ServicePassword:      "secret",
+				SlowRequestThreshold: tt.threshold,
+			}, logger)
+			require.NoError(t, err)
+
+			_, err = c.GetStudentByID(1)
+			require.NoError(t, err)
+
+			var warnings []*logrus.Entry
+			for _, entry := range hook.AllEntries() {
+				if entry.Level == logrus.WarnLevel {
+					warnings = append(warnings, entry)
+				}
+			}
+
+			if !tt.expectedWarn {
+				assert.Empty(t, warnings)
+				return
+			}
+
+			require.Len(t, warnings, 1)
+			assert.Equal(t, "Slow request to Node.js API", warnings[0].Message)
+			assert.Contains(t, warnings[0].Data["url"], "/students/1")
+			assert.NotEmpty(t, warnings[0].Data["duration"])
+		})
+	}
+}
+
+func TestNodeJSClient_TimeoutLog(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	})
+
+	logger, hook := test.NewNullLogger()
+	c, err := NewNodeJSClient(&config.NodeJSConfig{
+		BaseURL:         server.URL,
+		Timeout:         50 * time.Millisecond,
+		ServiceUsername: "admin@example.com",
+		ServicePassword: "secret",
+	}, logger)
+	require.NoError(t, err)
+
+	_, err = c.GetStudentByID(1)
+	require.Error(t, err)
+
+	var failures []*logrus.Entry
+	for _, entry := range hook.AllEntries() {
+		if entry.Message == "Request to Node.js API failed" {
+			failures = append(failures, entry)
+		}
+	}
+
+	require.NotEmpty(t, failures)
+	assert.Equal(t, logrus.WarnLevel, failures[0].Level)
+	assert.Contains(t, failures[0].Data["url"], "/students/1")
+	assert.NotEmpty(t, failures[0].Data["duration"])
+	assert.NotEmpty(t, failures[0].Data["error"])
+}
+
+// newTLSTestClient creates a client for an HTTPS fake API that trusts its certificate
+func newTLSTestClient(t *testing.T, cfg config.NodeJSConfig, server *httptest.Server) *NodeJSClient {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	cfg.BaseURL = server.URL
+	cfg.Timeout = 5 * time.Second
+	cfg.ServiceUsername = "admin@example.com"
+	cfg.ServicePassword = "secret"
+
+	c, err := NewNodeJSClient(&cfg, logger)
+	require.NoError(t, err)
+
+	roots := server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+	c.tlsConfig.RootCAs = roots
+	c.client.GetClient().Transport.(*http.Transport).TLSClientConfig.RootCAs = roots
+
+	return c
+}
+
+func newTLSTestServer(t *testing.T, maxVersion uint16) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(fakeAPI(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"success":true,"data":[],"message":"ok"}`)
+	}))
+	server.TLS = &tls.Config{MinVersion: tls.VersionTLS10, MaxVersion: maxVersion}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestNodeJSClient_TLSMinVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           config.NodeJSConfig
+		serverMax     uint16
+		expectedError bool
+	}{
+		{
+			name:      "Default minimum accepts TLS 1.2",
+			serverMax: tls.VersionTLS12,
+		},
+		{
+			name:          "Default minimum refuses TLS 1.1",
+			serverMax:     tls.VersionTLS11,
+			expectedError: true,
+		},
+		{
+			name:          "Configured minimum refuses older servers",
+			cfg:           config.NodeJSConfig{TLSMinVersion: "1.3"},
+			serverMax:     tls.VersionTLS12,
+			expectedError: true,
+		},
+		{
+			name:      "Allowed cipher suite",
+			cfg:       config.NodeJSConfig{TLSMaxVersion: "1.2", TLSCipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+			serverMax: tls.VersionTLS12,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTLSTestServer(t, tt.serverMax)
+			c := newTLSTestClient(t, tt.cfg, server)
+
+			_, err := c.GetAllStudents(nil)
+			healthErr := c.HealthCheck()
+
+			if tt.expectedError {
+				assert.ErrorContains(t, err, "protocol version")
+				assert.ErrorContains(t, healthErr, "protocol version")
+			} else {
+				assert.NoError(t, err)
+				assert.NoError(t, healthErr)
+			}
+		})
+	}
+}
+
+func TestNewNodeJSClient_InvalidTLSConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           config.NodeJSConfig
+		errorContains string
+	}{
+		{
+			name:          "Minimum above maximum",
+			cfg:           config.NodeJSConfig{TLSMinVersion: "1.3", TLSMaxVersion: "1.2"},
+			errorContains: "is above NODEJS_TLS_MAX_VERSION",
+		},
+		{
+			name:          "Unknown version",
+			cfg:           config.NodeJSConfig{TLSMinVersion: "1.4"},
+			errorContains: "NODEJS_TLS_MIN_VERSION must be one of",
+		},
+		{
+			name:          "Insecure cipher suite",
+			cfg:           config.NodeJSConfig{TLSCipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}},
+			errorContains: "TLS_RSA_WITH_RC4_128_SHA",
+		},
+		{
+			name:          "Cipher suites with TLS 1.3 only",
+			cfg:           config.NodeJSConfig{TLSMinVersion: "1.3", TLSCipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+			errorContains: "can't be used with TLS 1.3",
+		},
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewNodeJSClient(&tt.cfg, logger)
+			assert.ErrorContains(t, err, tt.errorContains)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// PhotoClient fetches student photos from the photo service
+type PhotoClient struct {
+	client *resty.Client
+	logger *logrus.Logger
+}
+
+// NewPhotoClient creates a photo service client
+func NewPhotoClient(cfg *config.PhotoConfig, logger *logrus.Logger) (*PhotoClient, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("logger cannot be nil")
+	}
+	if cfg.BaseURL == "" {
+		return nil, fmt.Errorf("photo base URL cannot be empty")
+	}
+
+	client := resty.New().
+		SetBaseURL(cfg.BaseURL).
+		SetTimeout(cfg.Timeout).
+		SetHeader("Accept", "image/*")
+
+	if cfg.AuthToken != "" {
+		client.SetAuthToken(cfg.AuthToken)
+	}
+
+	return &PhotoClient{
+		client: client,
+		logger: logger,
+	}, nil
+}
+
+// GetStudentPhoto fetches the photo for a student. It returns nil, nil when
+// the student has no photo (404) so callers can fall back to a placeholder.
+func (c *PhotoClient) GetStudentPhoto(studentID int) (*models.StudentPhoto, error) {
+	if studentID <= 0 {
+		return nil, fmt.Errorf("invalid student ID: %d", studentID)
+	}
+
+	endpoint := fmt.Sprintf("/students/%d/photo", studentID)
+
+	resp, err := c.client.R().Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("photo request failed: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		c.logger.WithField("student_id", studentID).Debug("No photo found for student")
+		return nil, nil
+	}
+
+	if resp.IsError() {
+		return nil, &ClientError{
+			StatusCode: resp.StatusCode(),
+			Message:    resp.Status(),
+			Details:    "photo service request failed",
+		}
+	}
+
+	return &models.StudentPhoto{
+		Data:        resp.Body(),
+		ContentType: resp.Header().Get("Content-Type"),
+	}, nil
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"student-report-service/internal/config"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhotoClient_GetStudentPhoto(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer photo-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/students/1/photo":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("png-bytes"))
+		case "/students/3/photo":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	c, err := NewPhotoClient(&config.PhotoConfig{
+		BaseURL:   server.URL,
+		AuthToken: "photo-token",
+		Timeout:   5 * time.Second,
+	}, logger)
+	require.NoError(t, err)
+
+	t.Run("Photo found", func(t *testing.T) {
+		photo, err := c.GetStudentPhoto(1)
+		require.NoError(t, err)
+		require.NotNil(t, photo)
+		assert.Equal(t, []byte("png-bytes"), photo.Data)
+		assert.Equal(t, "image/png", photo.ContentType)
+	})
+
+	t.Run("No photo", func(t *testing.T) {
+		photo, err := c.GetStudentPhoto(2)
+		assert.NoError(t, err)
+		assert.Nil(t, photo)
+	})
+
+	t.Run("Server error", func(t *testing.T) {
+		photo, err := c.GetStudentPhoto(3)
+		assert.Error(t, err)
+		assert.Nil(t, photo)
+	})
+}
+
+func TestNewPhotoClient_RequiresBaseURL(t *testing.T) {
+	_, err := NewPhotoClient(&config.PhotoConfig{}, logrus.New())
+	assert.Error(t, err)
+}
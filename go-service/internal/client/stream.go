@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"student-report-service/internal/models"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// maxErrorBodySize limits how much of an error response is read when streaming
+const maxErrorBodySize = 64 * 1024
+
+// StreamAllStudents fetches the filtered student list and calls fn for each
+// student as it is decoded from the response body, so the full list is never
+// held in memory. Streaming stops at the first error from fn or when ctx is
+// cancelled. Unlike GetAllStudents, incomplete responses are not retried
+// because fn may already have consumed part of the list.
+func (c *NodeJSClient) StreamAllStudents(ctx context.Context, filters map[string]string, fn func(models.StudentListItem) error) error {
+	if err := c.ensureAuthenticated(); err != nil {
+		return fmt.Errorf("authentication failed: %w", err)
+	}
+
+	endpoint := studentsEndpoint(filters)
+
+	c.logger.WithFields(logrus.Fields{
+		"endpoint": endpoint,
+		"filters":  filters,
+	}).Debug("Making authenticated request to stream all students")
+
+	resp, err := c.streamRequest(ctx, endpoint)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	body := resp.RawBody()
+	defer body.Close()
+
+	if resp.IsError() {
+		data, _ := io.ReadAll(io.LimitReader(body, maxErrorBodySize))
+		return responseError(resp, data)
+	}
+
+	apiResp, err := decodeStudentStream(ctx, json.NewDecoder(body), fn)
+	if err != nil {
+		return err
+	}
+
+	if !apiResp.Success {
+		return &ClientError{
+			StatusCode: resp.StatusCode(),
+			Message:    apiResp.Message,
+			Details:    "API returned success=false",
+		}
+	}
+
+	return nil
+}
+
+// streamRequest performs an authenticated GET whose body is left unread,
+// re-authenticating once on a 401. The caller must close the raw body.
+func (c *NodeJSClient) streamRequest(ctx context.Context, endpoint string) (*resty.Response, error) {
+	resp, err := c.newAuthenticatedRequest().
+		SetContext(ctx).
+		SetDoNotParseResponse(true).
+		Get(endpoint)
+
+	if err == nil && resp.StatusCode() == http.StatusUnauthorized {
+		resp.RawBody().Close()
+
+		c.logger.Debug("Received 401, attempting to re-authenticate")
+		if authErr := c.authenticate(); authErr != nil {
+			return nil, fmt.Errorf("re-authentication failed: %w", authErr)
+		}
+
+		resp, err = c.newAuthenticatedRequest().
+			SetContext(ctx).
+			SetDoNotParseResponse(true).
+			Get(endpoint)
+	}
+
+	return resp, err
+}
+
+// decodeStudentStream decodes a student list response one element of its
+// data array at a time. The returned response has no Data.
+func decodeStudentStream(ctx context.Context, decoder *json.Decoder, fn func(models.StudentListItem) error) (*models.StudentListResponse, error) {
+	apiResp := &models.StudentListResponse{}
+
+	if err := expectDelim(decoder, '{'); err != nil {
+		return nil, err
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, streamDecodeError(err)
+		}
+
+		switch token {
+		case "success":
+			err = decoder.Decode(&apiResp.Success)
+		case "message":
+			err = decoder.Decode(&apiResp.Message)
+		case "data":
+			err = decodeStudentArray(ctx, decoder, fn)
+		default:
+			var skipped json.RawMessage
+			err = decoder.Decode(&skipped)
+		}
+		if err != nil {
+			return nil, streamDecodeError(err)
+		}
+	}
+
+	if err := expectDelim(decoder, '}'); err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("%w: unexpected data after JSON body", ErrIncompleteResponse)
+	}
+
+	return apiResp, nil
+}
+
+// decodeStudentArray decodes the data array, calling fn for each student
+func decodeStudentArray(ctx context.Context, decoder *json.Decoder, fn func(models.StudentListItem) error) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if token != json.Delim('[') {
+		return fmt.Errorf("failed to unmarshal response: data is not an array")
+	}
+
+	for decoder.More() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var student models.StudentListItem
+		if err := decoder.Decode(&student); err != nil {
+			return err
+		}
+		if err := fn(student); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(decoder, ']')
+}
+
+// expectDelim reads the next token and checks that it is delim
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return streamDecodeError(err)
+	}
+	if token != delim {
+		return fmt.Errorf("failed to unmarshal response: expected %q, got %v", delim, token)
+	}
+	return nil
+}
+
+// streamDecodeError maps truncated or malformed JSON to ErrIncompleteResponse,
+// passing through errors from the callback and context
+func streamDecodeError(err error) error {
+	var syntaxErr *json.SyntaxError
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.As(err, &syntaxErr) {
+		return fmt.Errorf("%w: %v", ErrIncompleteResponse, err)
+	}
+	return err
+}
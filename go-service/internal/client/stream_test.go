@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeJSClient_StreamAllStudents(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		status        int
+		expectedIDs   []int
+		expectedError error
+	}{
+		{
+			name:        "Streams every student",
+			body:        `{"success":true,"data":[{"id":1,"name":"John"},{"id":2,"name":"Jane"}],"message":"ok"}`,
+			expectedIDs: []int{1, 2},
+		},
+		{
+			name:        "Null data",
+			body:        `{"success":true,"data":null,"message":"ok"}`,
+			expectedIDs: nil,
+		},
+		{
+			name:          "Truncated body",
+			body:          `{"success":true,"data":[{"id":1,"name":"John"},{"id":2,`,
+			expectedIDs:   []int{1},
+			expectedError: ErrIncompleteResponse,
+		},
+		{
+			name:          "Trailing data",
+			body:          `{"success":true,"data":[]}{"success"`,
+			expectedError: ErrIncompleteResponse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, tt.body)
+			})
+
+			var ids []int
+			err := newTestClient(t, server.URL).StreamAllStudents(context.Background(), nil, func(student models.StudentListItem) error {
+				ids = append(ids, student.ID)
+				return nil
+			})
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestNodeJSClient_StreamAllStudents_ErrorResponses(t *testing.T) {
+	t.Run("HTTP error", func(t *testing.T) {
+		server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"error":"not_found","message":"No students"}`)
+		})
+
+		err := newTestClient(t, server.URL).StreamAllStudents(context.Background(), nil, func(models.StudentListItem) error { return nil })
+
+		var clientErr *ClientError
+		require.ErrorAs(t, err, &clientErr)
+		assert.Equal(t, http.StatusNotFound, clientErr.StatusCode)
+		assert.Equal(t, "No students", clientErr.Message)
+	})
+
+	t.Run("Unsuccessful response", func(t *testing.T) {
+		server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"success":false,"message":"Database unavailable"}`)
+		})
+
+		err := newTestClient(t, server.URL).StreamAllStudents(context.Background(), nil, func(models.StudentListItem) error { return nil })
+
+		var clientErr *ClientError
+		require.ErrorAs(t, err, &clientErr)
+		assert.Equal(t, "Database unavailable", clientErr.Message)
+	})
+}
+
+func TestNodeJSClient_StreamAllStudents_Cancelled(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"success":true,"data":[`)
+		for i := 1; i <= 50000; i++ {
+			if i > 1 {
+				io.WriteString(w, ",")
+			}
+			fmt.Fprintf(w, `{"id":%d,"name":"Student %d"}`, i, i)
+		}
+		io.WriteString(w, `]}`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	count := 0
+	err := newTestClient(t, server.URL).StreamAllStudents(ctx, nil, func(models.StudentListItem) error {
+		count++
+		if count == 100 {
+			cancel()
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 100, count)
+}
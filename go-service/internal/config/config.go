@@ -1,9 +1,13 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
+
+	"student-report-service/internal/export"
 )
 
 // Config holds all configuration for the application
@@ -11,7 +15,15 @@ type Config struct {
 	Server  ServerConfig
 	NodeJS  NodeJSConfig
 	Report  ReportConfig
+	Photo   PhotoConfig
+	Health  HealthConfig
+	Filters FilterConfig
 	Logging LoggingConfig
+
+	// SafeMode disables every external integration, including the Node.js API
+	// and the photo service. Reports can only be rendered from supplied
+	// student data and are stored locally.
+	SafeMode bool
 }
 
 // ServerConfig contains server-related configuration
@@ -29,6 +41,14 @@ type NodeJSConfig struct {
 	RetryAttempts int           `env:"NODEJS_RETRY_ATTEMPTS" default:"3"`
 	RetryDelay    time.Duration `env:"NODEJS_RETRY_DELAY" default:"1s"`
 
+	// Requests slower than this are logged at warn level; 0 disables the log
+	SlowRequestThreshold time.Duration `env:"NODEJS_SLOW_REQUEST_THRESHOLD" default:"2s"`
+
+	// TLS policy for HTTPS connections, applied by TLSClientConfig
+	TLSMinVersion   string   `env:"NODEJS_TLS_MIN_VERSION" default:"1.2"`
+	TLSMaxVersion   string   `env:"NODEJS_TLS_MAX_VERSION"`
+	TLSCipherSuites []string `env:"NODEJS_TLS_CIPHER_SUITES"`
+
 	// Authentication for service-to-service communication
 	ServiceUsername string `env:"NODEJS_SERVICE_USERNAME" default:"admin@school-admin.com"`
 	ServicePassword string `env:"NODEJS_SERVICE_PASSWORD" default:"3OU4zn3q6Zh9"`
@@ -36,17 +56,218 @@ type NodeJSConfig struct {
 
 // ReportConfig contains PDF report generation configuration
 type ReportConfig struct {
-	OutputDir     string
-	MaxFileSize   int64
-	Cleanup       bool
-	CleanupAfter  time.Duration
-	WatermarkText string
+	OutputDir    string
+	MaxFileSize  int64
+	Cleanup      bool
+	CleanupAfter time.Duration
+	// Number of files removed concurrently by CleanupOldReports
+	CleanupWorkers int
+	WatermarkText  string
+	CSVQuoting     string
+	TextOverflow   string
+	// Column plain-text reports are wrapped at
+	TextWidth int
+
+	// WatermarkRulesSpec selects watermarks by report status and requesting
+	// role; LoadWatermarkRules parses it into WatermarkRules
+	WatermarkRulesSpec string
+	WatermarkRules     []WatermarkRule
+
+	// RequiredOptionsSpec lists the options reports of a status or profile
+	// must set; LoadRequiredOptions parses it into RequiredOptionsRules
+	RequiredOptionsSpec  string
+	RequiredOptionsRules []RequiredOptionsRule
+
+	// Named report profiles, loaded from ProfilesFile by LoadProfiles
+	ProfilesFile string
+	Profiles     map[string]ReportProfile
+
+	// Cover letter template, given inline or read from CoverLetterFile by LoadCoverLetter
+	CoverLetterTemplate string
+	CoverLetterFile     string
+
+	// EnricherFailureMode is EnricherFailureFail or EnricherFailureWarn
+	EnricherFailureMode string
+
+	// WarmStudentIDs lists the students whose reports are generated ahead of
+	// demand, comma-separated; see WarmStudents
+	WarmStudentIDs      string
+	WarmRefreshInterval time.Duration
+
+	// MaxPages caps the pages in a single report; 0 disables the limit.
+	// PageLimitMode is PageLimitTruncate or PageLimitFail.
+	MaxPages      int
+	PageLimitMode string
+
+	// CollisionMode is ReportCollisionRegenerate or ReportCollisionFail
+	CollisionMode string
+
+	// RetryAttempts re-runs a failed student report up to this many times on
+	// retryable failures, waiting RetryBackoff before the first retry and
+	// twice as long before each next one; 0 disables retries
+	RetryAttempts int
+	RetryBackoff  time.Duration
+
+	// ReadOnly disables generating and cleaning up reports; existing
+	// reports can still be read
+	ReadOnly bool
+
+	// Index keeps an in-memory index of report files for lookups by report ID
+	Index bool
+
+	// GeneratedByBlocklist holds reserved names external callers may not
+	// give as generated_by, compared case-insensitively
+	GeneratedByBlocklist []string
+
+	// VerificationSecret signs a detached verification record saved with
+	// each report; empty disables verification records
+	VerificationSecret string
+
+	// Timezone is the IANA zone report times are displayed in and
+	// UpstreamTimezone the zone upstream dates take their day in; see
+	// DisplayLocation and UpstreamLocation for the fallbacks
+	Timezone         string
+	UpstreamTimezone string
+}
+
+// GeneratedByBlocked reports whether generatedBy is a reserved name
+func (c *ReportConfig) GeneratedByBlocked(generatedBy string) bool {
+	generatedBy = strings.TrimSpace(generatedBy)
+	for _, blocked := range c.GeneratedByBlocklist {
+		if strings.EqualFold(blocked, generatedBy) {
+			return true
+		}
+	}
+	return false
 }
 
+// PhotoConfig contains configuration for the student photo service.
+// Photos are only embedded in reports when BaseURL is set.
+type PhotoConfig struct {
+	BaseURL   string
+	AuthToken string
+	Timeout   time.Duration
+}
+
+// HealthConfig selects the components probed by the health check and
+// which of them are critical. An empty Components list probes every
+// component in HealthComponents, and an empty Critical list makes every
+// component critical.
+type HealthConfig struct {
+	Components []string
+	Critical   []string
+}
+
+// FilterConfig restricts which student list filter keys callers may use.
+// An empty Allowed list allows every key not in Denied.
+type FilterConfig struct {
+	Allowed []string
+	Denied  []string
+}
+
+// FilterAllowed reports whether callers may filter the student list by key
+func (c *FilterConfig) FilterAllowed(key string) bool {
+	for _, denied := range c.Denied {
+		if denied == key {
+			return false
+		}
+	}
+
+	if len(c.Allowed) == 0 {
+		return true
+	}
+	for _, allowed := range c.Allowed {
+		if allowed == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Health check components
+const (
+	HealthComponentNodeJSAPI    = "nodejs_api"
+	HealthComponentPDFGenerator = "pdf_generator"
+)
+
+// HealthComponents lists every component the health check can probe
+var HealthComponents = []string{HealthComponentNodeJSAPI, HealthComponentPDFGenerator}
+
+// HealthComponentEnabled reports whether the health check probes the named component
+func (c *HealthConfig) HealthComponentEnabled(name string) bool {
+	if len(c.Components) == 0 {
+		return true
+	}
+	for _, component := range c.Components {
+		if component == name {
+			return true
+		}
+	}
+	return false
+}
+
+// HealthComponentCritical reports whether a failure of the named component
+// makes the service unhealthy rather than degraded
+func (c *HealthConfig) HealthComponentCritical(name string) bool {
+	if len(c.Critical) == 0 {
+		return true
+	}
+	for _, component := range c.Critical {
+		if component == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkHealthComponents rejects names that are not in HealthComponents
+func checkHealthComponents(envName string, components []string) error {
+	for _, component := range components {
+		known := false
+		for _, name := range HealthComponents {
+			known = known || component == name
+		}
+		if !known {
+			return fmt.Errorf("%s: unknown component %q, expected one of %s", envName, component, strings.Join(HealthComponents, ", "))
+		}
+	}
+	return nil
+}
+
+// Enricher failure modes
+const (
+	// EnricherFailureFail aborts the report when an enricher fails
+	EnricherFailureFail = "fail"
+	// EnricherFailureWarn records a warning on the report and continues
+	EnricherFailureWarn = "warn"
+)
+
+// Page limit modes
+const (
+	// PageLimitTruncate cuts the report at the limit with a notice and a warning
+	PageLimitTruncate = "truncate"
+	// PageLimitFail aborts reports that would exceed the limit
+	PageLimitFail = "fail"
+)
+
+// Report collision modes, for a report whose file already exists
+const (
+	// ReportCollisionRegenerate retries with a new report ID and file name
+	ReportCollisionRegenerate = "regenerate"
+	// ReportCollisionFail aborts the report
+	ReportCollisionFail = "fail"
+)
+
+// MinTextWidth is the narrowest column plain-text reports can be wrapped at
+const MinTextWidth = 40
+
 // LoggingConfig contains logging configuration
 type LoggingConfig struct {
 	Level  string
 	Format string
+	// RedactPII hashes student names, emails and contact details in logs and
+	// returned error messages, keeping numeric IDs for correlation
+	RedactPII bool
 }
 
 // Load loads configuration from environment variables with sensible defaults
@@ -59,24 +280,77 @@ func Load() *Config {
 			IdleTimeout:  getDurationEnv("IDLE_TIMEOUT", 60*time.Second),
 		},
 		NodeJS: NodeJSConfig{
-			BaseURL:         getEnv("NODEJS_API_URL", "http://localhost:5007/api/v1"),
-			Timeout:         getDurationEnv("NODEJS_TIMEOUT", 30*time.Second),
-			RetryAttempts:   getIntEnv("NODEJS_RETRY_ATTEMPTS", 3),
-			RetryDelay:      getDurationEnv("NODEJS_RETRY_DELAY", 1*time.Second),
-			ServiceUsername: getEnv("NODEJS_SERVICE_USERNAME", "admin@school-admin.com"),
-			ServicePassword: getEnv("NODEJS_SERVICE_PASSWORD", "3OU4zn3q6Zh9"),
+			BaseURL:       getEnv("NODEJS_API_URL", "http://localhost:5007/api/v1"),
+			Timeout:       getDurationEnv("NODEJS_TIMEOUT", 30*time.Second),
+			RetryAttempts: getIntEnv("NODEJS_RETRY_ATTEMPTS", 3),
+			RetryDelay:    getDurationEnv("NODEJS_RETRY_DELAY", 1*time.Second),
+
+			SlowRequestThreshold: getDurationEnv("NODEJS_SLOW_REQUEST_THRESHOLD", 2*time.Second),
+			TLSMinVersion:        getEnv("NODEJS_TLS_MIN_VERSION", DefaultTLSMinVersion),
+			TLSMaxVersion:        getEnv("NODEJS_TLS_MAX_VERSION", ""),
+			TLSCipherSuites:      getStringListEnv("NODEJS_TLS_CIPHER_SUITES", nil),
+			ServiceUsername:      getEnv("NODEJS_SERVICE_USERNAME", "admin@school-admin.com"),
+			ServicePassword:      getEnv("NODEJS_SERVICE_PASSWORD", "3OU4zn3q6Zh9"),
 		},
 		Report: ReportConfig{
-			OutputDir:     getEnv("REPORT_OUTPUT_DIR", "./reports"),
-			MaxFileSize:   getInt64Env("REPORT_MAX_FILE_SIZE", 10*1024*1024), // 10MB
-			Cleanup:       getBoolEnv("REPORT_CLEANUP", true),
-			CleanupAfter:  getDurationEnv("REPORT_CLEANUP_AFTER", 24*time.Hour),
-			WatermarkText: getEnv("REPORT_WATERMARK", "Student Management System - Confidential"),
+			OutputDir:      getEnv("REPORT_OUTPUT_DIR", "./reports"),
+			MaxFileSize:    getInt64Env("REPORT_MAX_FILE_SIZE", 10*1024*1024), // 10MB
+			Cleanup:        getBoolEnv("REPORT_CLEANUP", true),
+			CleanupAfter:   getDurationEnv("REPORT_CLEANUP_AFTER", 24*time.Hour),
+			CleanupWorkers: getIntEnv("REPORT_CLEANUP_WORKERS", 4),
+			WatermarkText:  getEnv("REPORT_WATERMARK", "Student Management System - Confidential"),
+			CSVQuoting:     getEnv("REPORT_CSV_QUOTING", "minimal"),
+			TextOverflow:   getEnv("REPORT_TEXT_OVERFLOW", "wrap"),
+			TextWidth:      getIntEnv("REPORT_TEXT_WIDTH", 80),
+			ProfilesFile:   getEnv("REPORT_PROFILES_FILE", ""),
+
+			WatermarkRulesSpec: getEnv("REPORT_WATERMARK_RULES", ""),
+
+			RequiredOptionsSpec: getEnv("REPORT_REQUIRED_OPTIONS", ""),
+
+			CoverLetterTemplate: getEnv("REPORT_COVER_LETTER_TEMPLATE", ""),
+			CoverLetterFile:     getEnv("REPORT_COVER_LETTER_FILE", ""),
+
+			EnricherFailureMode: getEnv("REPORT_ENRICHER_FAILURE_MODE", EnricherFailureFail),
+
+			WarmStudentIDs:      getEnv("REPORT_WARM_STUDENT_IDS", ""),
+			WarmRefreshInterval: getDurationEnv("REPORT_WARM_REFRESH_INTERVAL", 15*time.Minute),
+
+			MaxPages:      getIntEnv("REPORT_MAX_PAGES", 50),
+			PageLimitMode: getEnv("REPORT_PAGE_LIMIT_MODE", PageLimitTruncate),
+			CollisionMode: getEnv("REPORT_COLLISION_MODE", ReportCollisionRegenerate),
+			ReadOnly:      getBoolEnv("REPORT_READ_ONLY", false),
+
+			Index: getBoolEnv("REPORT_INDEX", true),
+
+			RetryAttempts: getIntEnv("REPORT_RETRY_ATTEMPTS", 0),
+			RetryBackoff:  getDurationEnv("REPORT_RETRY_BACKOFF", 1*time.Second),
+
+			GeneratedByBlocklist: getStringListEnv("REPORT_GENERATED_BY_BLOCKLIST", nil),
+			VerificationSecret:   getEnv("REPORT_VERIFICATION_SECRET", ""),
+
+			Timezone:         getEnv("REPORT_TIMEZONE", ""),
+			UpstreamTimezone: getEnv("REPORT_UPSTREAM_TIMEZONE", ""),
+		},
+		Photo: PhotoConfig{
+			BaseURL:   getEnv("PHOTO_BASE_URL", ""),
+			AuthToken: getEnv("PHOTO_AUTH_TOKEN", ""),
+			Timeout:   getDurationEnv("PHOTO_TIMEOUT", 10*time.Second),
+		},
+		Health: HealthConfig{
+			Components: getStringListEnv("HEALTH_CHECK_COMPONENTS", nil),
+			Critical:   getStringListEnv("HEALTH_CRITICAL_COMPONENTS", nil),
+		},
+		Filters: FilterConfig{
+			Allowed: getStringListEnv("STUDENT_FILTER_ALLOWLIST", nil),
+			Denied:  getStringListEnv("STUDENT_FILTER_DENYLIST", nil),
 		},
 		Logging: LoggingConfig{
-			Level:  getEnv("LOG_LEVEL", "info"),
-			Format: getEnv("LOG_FORMAT", "json"),
+			Level:     getEnv("LOG_LEVEL", "info"),
+			Format:    getEnv("LOG_FORMAT", "json"),
+			RedactPII: getBoolEnv("LOG_REDACT_PII", false),
 		},
+		SafeMode: getBoolEnv("SAFE_MODE", false),
 	}
 }
 
@@ -116,6 +390,21 @@ func getBoolEnv(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+func getStringListEnv(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var result []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
 func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -127,6 +416,78 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 
 // Validate validates the configuration
 func (c *Config) Validate() error {
-	// Add validation logic here if needed
+	if _, err := c.NodeJS.TLSClientConfig(); err != nil {
+		return err
+	}
+
+	// Validated by the parser the export uses, so both accept the same values
+	if _, err := export.ParseQuoteMode(c.Report.CSVQuoting); err != nil {
+		return fmt.Errorf("REPORT_CSV_QUOTING must be \"minimal\" or \"all\", got %q", c.Report.CSVQuoting)
+	}
+
+	switch c.Report.EnricherFailureMode {
+	case "", EnricherFailureFail, EnricherFailureWarn:
+	default:
+		return fmt.Errorf("REPORT_ENRICHER_FAILURE_MODE must be %q or %q, got %q", EnricherFailureFail, EnricherFailureWarn, c.Report.EnricherFailureMode)
+	}
+
+	switch strings.ToLower(c.Report.TextOverflow) {
+	case "", "wrap", "truncate":
+	default:
+		return fmt.Errorf("REPORT_TEXT_OVERFLOW must be \"wrap\" or \"truncate\", got %q", c.Report.TextOverflow)
+	}
+
+	if _, err := c.Report.DisplayLocation(); err != nil {
+		return err
+	}
+
+	if _, err := c.Report.UpstreamLocation(); err != nil {
+		return err
+	}
+
+	if _, err := c.Report.WarmStudents(); err != nil {
+		return err
+	}
+
+	if c.Report.TextWidth != 0 && c.Report.TextWidth < MinTextWidth {
+		return fmt.Errorf("REPORT_TEXT_WIDTH must be at least %d, got %d", MinTextWidth, c.Report.TextWidth)
+	}
+
+	if c.Report.CleanupWorkers < 0 {
+		return fmt.Errorf("REPORT_CLEANUP_WORKERS cannot be negative, got %d", c.Report.CleanupWorkers)
+	}
+
+	if c.Report.RetryAttempts < 0 {
+		return fmt.Errorf("REPORT_RETRY_ATTEMPTS cannot be negative, got %d", c.Report.RetryAttempts)
+	}
+
+	if c.Report.RetryBackoff < 0 {
+		return fmt.Errorf("REPORT_RETRY_BACKOFF cannot be negative, got %s", c.Report.RetryBackoff)
+	}
+
+	if c.Report.MaxPages < 0 {
+		return fmt.Errorf("REPORT_MAX_PAGES cannot be negative, got %d", c.Report.MaxPages)
+	}
+
+	switch c.Report.PageLimitMode {
+	case "", PageLimitTruncate, PageLimitFail:
+	default:
+		return fmt.Errorf("REPORT_PAGE_LIMIT_MODE must be %q or %q, got %q", PageLimitTruncate, PageLimitFail, c.Report.PageLimitMode)
+	}
+
+	switch c.Report.CollisionMode {
+	case "", ReportCollisionRegenerate, ReportCollisionFail:
+	default:
+		return fmt.Errorf("REPORT_COLLISION_MODE must be %q or %q, got %q", ReportCollisionRegenerate, ReportCollisionFail, c.Report.CollisionMode)
+	}
+
+	if err := checkHealthComponents("HEALTH_CHECK_COMPONENTS", c.Health.Components); err != nil {
+		return err
+	}
+
+	if err := checkHealthComponents("HEALTH_CRITICAL_COMPONENTS", c.Health.Critical); err != nil {
+		return err
+	}
+
 	return nil
 }
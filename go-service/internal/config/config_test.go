@@ -0,0 +1,93 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_ValidateCSVQuoting(t *testing.T) {
+	tests := []struct {
+		name        string
+		quoting     string
+		expectError bool
+	}{
+		{name: "Unset", quoting: ""},
+		{name: "Minimal", quoting: "minimal"},
+		{name: "Mixed case with spaces", quoting: " All "},
+		{name: "Unknown mode", quoting: "none", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Report: ReportConfig{CSVQuoting: tt.quoting}}
+			err := cfg.Validate()
+
+			if tt.expectError {
+				assert.ErrorContains(t, err, "REPORT_CSV_QUOTING")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateTimezones(t *testing.T) {
+	tests := []struct {
+		name          string
+		timezone      string
+		upstream      string
+		expectedError string
+	}{
+		{name: "Unset"},
+		{name: "Both valid", timezone: "Asia/Kolkata", upstream: "UTC"},
+		{name: "Bad display zone", timezone: "Mars/Olympus", expectedError: "REPORT_TIMEZONE"},
+		{name: "Bad display zone with a valid upstream zone", timezone: "Mars/Olympus", upstream: "UTC", expectedError: "REPORT_TIMEZONE"},
+		{name: "Bad upstream zone", timezone: "Asia/Kolkata", upstream: "Mars/Olympus", expectedError: "REPORT_UPSTREAM_TIMEZONE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Report: ReportConfig{Timezone: tt.timezone, UpstreamTimezone: tt.upstream}}
+			err := cfg.Validate()
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReportConfig_WarmStudents(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expected      []int
+		expectedError string
+	}{
+		{name: "Unset"},
+		{name: "Valid list", value: "12, 7,3", expected: []int{12, 7, 3}},
+		{name: "Word", value: "12,abc,0x5", expectedError: `invalid student ID "abc"`},
+		{name: "Hex", value: "12,0x5", expectedError: `invalid student ID "0x5"`},
+		{name: "Zero", value: "12,0", expectedError: `invalid student ID "0"`},
+		{name: "Empty entry", value: "12,,7", expectedError: `invalid student ID ""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Report: ReportConfig{WarmStudentIDs: tt.value}}
+			ids, err := cfg.Report.WarmStudents()
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.ErrorContains(t, cfg.Validate(), tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ids)
+			assert.NoError(t, cfg.Validate())
+		})
+	}
+}
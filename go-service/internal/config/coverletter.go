@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+)
+
+// CoverLetterOptions enables a cover letter as the first page of a profile's reports
+type CoverLetterOptions struct {
+	// Purpose is available to the template as {{.Purpose}}
+	Purpose string `json:"purpose"`
+}
+
+// LoadCoverLetter reads the cover letter template from CoverLetterFile, if
+// configured, and checks that it parses. It must run after LoadProfiles so
+// that profiles enabling a cover letter can be checked for a template.
+func (c *ReportConfig) LoadCoverLetter() error {
+	if c.CoverLetterFile != "" {
+		data, err := os.ReadFile(c.CoverLetterFile)
+		if err != nil {
+			return fmt.Errorf("failed to read cover letter template: %w", err)
+		}
+		c.CoverLetterTemplate = string(data)
+	}
+
+	if c.CoverLetterTemplate != "" {
+		if _, err := ParseCoverLetterTemplate(c.CoverLetterTemplate); err != nil {
+			return err
+		}
+	}
+
+	for name, profile := range c.Profiles {
+		if profile.CoverLetter != nil && c.CoverLetterTemplate == "" {
+			return fmt.Errorf("profile %q enables a cover letter but no cover letter template is configured", name)
+		}
+	}
+
+	return nil
+}
+
+// ParseCoverLetterTemplate parses a cover letter template. Executing it fails
+// on any variable that isn't provided, rather than rendering "<no value>".
+func ParseCoverLetterTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("cover_letter").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cover letter template: %w", err)
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultProfileName is the profile applied when callers don't ask for one
+const DefaultProfileName = "default"
+
+// ReportProfile bundles the per-report rendering options callers would
+// otherwise have to repeat at every call site
+type ReportProfile struct {
+	// Format is the output format, ReportFormatPDF or ReportFormatText
+	Format string `json:"format"`
+	// Watermark overrides REPORT_WATERMARK; an empty string disables the watermark
+	Watermark *string `json:"watermark,omitempty"`
+	// CoverLetter adds a cover letter rendered from the configured template
+	CoverLetter *CoverLetterOptions `json:"cover_letter,omitempty"`
+	// Sign saves a signed verification record with each report. Unset signs
+	// whenever REPORT_VERIFICATION_SECRET is set; true requires the secret.
+	Sign *bool `json:"sign,omitempty"`
+}
+
+// Report formats
+const (
+	// ReportFormatPDF renders reports as PDF documents
+	ReportFormatPDF = "pdf"
+	// ReportFormatText renders reports as plain text wrapped at REPORT_TEXT_WIDTH
+	ReportFormatText = "text"
+)
+
+// supportedReportFormats lists the formats a profile may select
+var supportedReportFormats = map[string]bool{
+	ReportFormatPDF:  true,
+	ReportFormatText: true,
+}
+
+// Profile returns the named report profile. The "default" profile is always
+// available and matches the service defaults unless overridden in the profiles file.
+func (c *ReportConfig) Profile(name string) (ReportProfile, error) {
+	if name == "" {
+		name = DefaultProfileName
+	}
+
+	if profile, ok := c.Profiles[name]; ok {
+		return profile, nil
+	}
+
+	if name == DefaultProfileName {
+		return ReportProfile{Format: ReportFormatPDF}, nil
+	}
+
+	return ReportProfile{}, fmt.Errorf("report profile %q not found", name)
+}
+
+// LoadProfiles reads named report profiles from ProfilesFile, if configured
+func (c *ReportConfig) LoadProfiles() error {
+	if c.ProfilesFile == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(c.ProfilesFile)
+	if err != nil {
+		return fmt.Errorf("failed to read report profiles file: %w", err)
+	}
+
+	profiles, err := parseProfiles(data)
+	if err != nil {
+		return fmt.Errorf("invalid report profiles file %s: %w", c.ProfilesFile, err)
+	}
+
+	for name, profile := range profiles {
+		if profile.Sign != nil && *profile.Sign && c.VerificationSecret == "" {
+			return fmt.Errorf("invalid report profiles file %s: profile %q signs reports, so REPORT_VERIFICATION_SECRET must be set", c.ProfilesFile, name)
+		}
+	}
+
+	c.Profiles = profiles
+	return nil
+}
+
+// SignsReports reports whether reports rendered with profile are saved with
+// a signed verification record
+func (c *ReportConfig) SignsReports(profile ReportProfile) bool {
+	if c.VerificationSecret == "" {
+		return false
+	}
+	return profile.Sign == nil || *profile.Sign
+}
+
+// parseProfiles decodes and validates a JSON object of profile name to profile
+func parseProfiles(data []byte) (map[string]ReportProfile, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Reject options this service doesn't implement instead of silently ignoring them
+	decoder.DisallowUnknownFields()
+
+	var profiles map[string]ReportProfile
+	if err := decoder.Decode(&profiles); err != nil {
+		return nil, err
+	}
+
+	for name, profile := range profiles {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("profile name cannot be empty")
+		}
+
+		if profile.Format == "" {
+			profile.Format = ReportFormatPDF
+		}
+		profile.Format = strings.ToLower(profile.Format)
+		if !supportedReportFormats[profile.Format] {
+			return nil, fmt.Errorf("profile %q: unsupported format %q", name, profile.Format)
+		}
+
+		profiles[name] = profile
+	}
+
+	return profiles, nil
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProfiles(t *testing.T) {
+	draft := "DRAFT"
+
+	tests := []struct {
+		name          string
+		data          string
+		expected      map[string]ReportProfile
+		expectedError string
+	}{
+		{
+			name: "Formats default to PDF and ignore case",
+			data: `{"official": {}, "draft": {"format": "PDF", "watermark": "DRAFT"}, "plain": {"format": "Text"}}`,
+			expected: map[string]ReportProfile{
+				"official": {Format: ReportFormatPDF},
+				"draft":    {Format: ReportFormatPDF, Watermark: &draft},
+				"plain":    {Format: ReportFormatText},
+			},
+		},
+		{
+			name:          "Malformed JSON",
+			data:          `{"draft": {"format": "pdf"`,
+			expectedError: "unexpected EOF",
+		},
+		{
+			name:          "Not an object of profiles",
+			data:          `["draft"]`,
+			expectedError: "cannot unmarshal array",
+		},
+		{
+			name:          "Unknown format",
+			data:          `{"parent": {"format": "html"}}`,
+			expectedError: `profile "parent": unsupported format "html"`,
+		},
+		{
+			name:          "Unknown option",
+			data:          `{"official": {"signing": true}}`,
+			expectedError: `unknown field "signing"`,
+		},
+		{
+			name:          "Empty profile name",
+			data:          `{" ": {}}`,
+			expectedError: "profile name cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profiles, err := parseProfiles([]byte(tt.data))
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, profiles)
+		})
+	}
+}
+
+func TestReportConfig_LoadProfilesSigning(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"official": {"sign": true}, "internal": {"sign": false}}`), 0644))
+
+	t.Run("Signing profile needs a secret", func(t *testing.T) {
+		cfg := &ReportConfig{ProfilesFile: path}
+		err := cfg.LoadProfiles()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `profile "official" signs reports, so REPORT_VERIFICATION_SECRET must be set`)
+	})
+
+	t.Run("Profiles choose whether reports are signed", func(t *testing.T) {
+		cfg := &ReportConfig{ProfilesFile: path, VerificationSecret: "secret"}
+		require.NoError(t, cfg.LoadProfiles())
+
+		for name, expected := range map[string]bool{"official": true, "internal": false, DefaultProfileName: true} {
+			profile, err := cfg.Profile(name)
+			require.NoError(t, err)
+			assert.Equal(t, expected, cfg.SignsReports(profile), name)
+		}
+
+		cfg.VerificationSecret = ""
+		assert.False(t, cfg.SignsReports(cfg.Profiles["official"]))
+	})
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"regexp"
+	"time"
+)
+
+// RedactedValue replaces secrets in a redacted configuration
+const RedactedValue = "[REDACTED]"
+
+// secretFieldPattern matches the names of fields holding credentials. New
+// secrets are masked without further changes as long as their names match.
+var secretFieldPattern = regexp.MustCompile(`(?i)password|secret|token|key`)
+
+// Redacted returns a copy of the configuration that is safe to print: string
+// fields named like passwords, secrets, tokens or keys are masked, as are
+// passwords embedded in URLs. Unset secrets stay empty, so it remains
+// visible whether they are configured. Maps and slices are shared with c.
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	redactStruct(reflect.ValueOf(&redacted).Elem())
+	return &redacted
+}
+
+// RedactedJSON returns the redacted configuration as indented JSON, with
+// durations written like "30s"
+func (c *Config) RedactedJSON() ([]byte, error) {
+	return json.MarshalIndent(plainValue(reflect.ValueOf(*c.Redacted())), "", "  ")
+}
+
+// redactStruct masks the secrets in v, a settable struct value, and in the
+// structs it embeds by value
+func redactStruct(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+		switch field.Kind() {
+		case reflect.Struct:
+			redactStruct(field)
+		case reflect.String:
+			if field.String() == "" {
+				continue
+			}
+			if secretFieldPattern.MatchString(v.Type().Field(i).Name) {
+				field.SetString(RedactedValue)
+			} else {
+				field.SetString(redactURL(field.String()))
+			}
+		}
+	}
+}
+
+// redactURL masks the password of a URL with credentials and returns other
+// values unchanged
+func redactURL(value string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil {
+		return value
+	}
+	if _, ok := u.User.Password(); !ok {
+		return value
+	}
+	return u.Redacted()
+}
+
+// plainValue converts configuration values for JSON encoding: structs become
+// objects keyed by field name and durations strings
+func plainValue(v reflect.Value) interface{} {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		return time.Duration(v.Int()).String()
+	}
+	if v.Kind() != reflect.Struct {
+		return v.Interface()
+	}
+
+	fields := make(map[string]interface{}, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).IsExported() {
+			fields[v.Type().Field(i).Name] = plainValue(v.Field(i))
+		}
+	}
+	return fields
+}
@@ -0,0 +1,29 @@
+package config
+
+import "strings"
+
+// ReportStatusFinal is the status of reports requested without one
+const ReportStatusFinal = "final"
+
+// ReportContext is the status of a requested report and the role of the
+// requester. It selects conditional watermarks and required options. Role
+// is optional.
+type ReportContext struct {
+	Status string
+	Role   string
+}
+
+// normalized returns the context lower-cased, with an empty status read as final
+func (c ReportContext) normalized() ReportContext {
+	c.Status = strings.ToLower(strings.TrimSpace(c.Status))
+	c.Role = strings.ToLower(strings.TrimSpace(c.Role))
+	if c.Status == "" {
+		c.Status = ReportStatusFinal
+	}
+	return c
+}
+
+// IsZero reports whether the context is the default: final status and no role
+func (c ReportContext) IsZero() bool {
+	return c.normalized() == ReportContext{Status: ReportStatusFinal}
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Report options a required-options rule can demand
+const (
+	// RequiredOnBehalfOf requires the person the report is for (on_behalf_of)
+	RequiredOnBehalfOf = "on_behalf_of"
+	// RequiredRole requires the requesting role (role)
+	RequiredRole = "role"
+	// RequiredCoverLetter requires a profile with a cover letter
+	RequiredCoverLetter = "cover_letter"
+	// RequiredWatermark requires a non-empty watermark
+	RequiredWatermark = "watermark"
+	// RequiredSignature requires a profile that saves a signed verification record
+	RequiredSignature = "signature"
+)
+
+// requiredOptionOrder lists the options a rule may require, in the order missing options are reported
+var requiredOptionOrder = []string{
+	RequiredOnBehalfOf,
+	RequiredRole,
+	RequiredCoverLetter,
+	RequiredWatermark,
+	RequiredSignature,
+}
+
+// AnyReportStatus matches reports of every status in a required-options rule
+const AnyReportStatus = "*"
+
+// RequiredOptionsRule lists the options reports with Status, rendered with
+// Profile when it is set or with any profile otherwise, must set
+type RequiredOptionsRule struct {
+	Status  string   `json:"status"`
+	Profile string   `json:"profile,omitempty"`
+	Options []string `json:"options"`
+}
+
+// LoadRequiredOptions parses RequiredOptionsSpec, a comma-separated list of
+// status=option+option or status:profile=option+option entries, into
+// RequiredOptionsRules. Profiles must be loaded first.
+func (c *ReportConfig) LoadRequiredOptions() error {
+	c.RequiredOptionsRules = nil
+	if strings.TrimSpace(c.RequiredOptionsSpec) == "" {
+		return nil
+	}
+
+	supported := make(map[string]bool, len(requiredOptionOrder))
+	for _, option := range requiredOptionOrder {
+		supported[option] = true
+	}
+
+	type selector struct{ status, profile string }
+	seen := make(map[selector]bool)
+	for _, entry := range strings.Split(c.RequiredOptionsSpec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		selectorSpec, optionsSpec, ok := strings.Cut(entry, "=")
+		if !ok {
+			return fmt.Errorf("REPORT_REQUIRED_OPTIONS: entry %q must be status[:profile]=option[+option]", entry)
+		}
+		status, profile, _ := strings.Cut(selectorSpec, ":")
+		status = strings.ToLower(strings.TrimSpace(status))
+		profile = strings.TrimSpace(profile)
+		if status == "" {
+			return fmt.Errorf("REPORT_REQUIRED_OPTIONS: entry %q has no status", entry)
+		}
+		if profile != "" {
+			if _, err := c.Profile(profile); err != nil {
+				return fmt.Errorf("REPORT_REQUIRED_OPTIONS: entry %q: %w", entry, err)
+			}
+		}
+
+		key := selector{status, profile}
+		if seen[key] {
+			return fmt.Errorf("REPORT_REQUIRED_OPTIONS: duplicate rule for %q", strings.TrimSpace(selectorSpec))
+		}
+		seen[key] = true
+
+		rule := RequiredOptionsRule{Status: status, Profile: profile}
+		for _, option := range strings.Split(optionsSpec, "+") {
+			option = strings.ToLower(strings.TrimSpace(option))
+			if !supported[option] {
+				return fmt.Errorf("REPORT_REQUIRED_OPTIONS: entry %q: unknown option %q, must be one of %s", entry, option, strings.Join(requiredOptionOrder, ", "))
+			}
+			rule.Options = append(rule.Options, option)
+		}
+		c.RequiredOptionsRules = append(c.RequiredOptionsRules, rule)
+	}
+	return nil
+}
+
+// RequiredOptions returns the options a report with the status in
+// reportContext, rendered with the named profile, must set. Every matching
+// rule applies.
+func (c *ReportConfig) RequiredOptions(reportContext ReportContext, profileName string) []string {
+	if profileName == "" {
+		profileName = DefaultProfileName
+	}
+	status := reportContext.normalized().Status
+
+	required := make(map[string]bool)
+	for _, rule := range c.RequiredOptionsRules {
+		if rule.Status != AnyReportStatus && rule.Status != status {
+			continue
+		}
+		if rule.Profile != "" && rule.Profile != profileName {
+			continue
+		}
+		for _, option := range rule.Options {
+			required[option] = true
+		}
+	}
+
+	var options []string
+	for _, option := range requiredOptionOrder {
+		if required[option] {
+			options = append(options, option)
+		}
+	}
+	return options
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportConfig_LoadRequiredOptions(t *testing.T) {
+	profiles := map[string]ReportProfile{"parent": {Format: ReportFormatPDF}}
+
+	tests := []struct {
+		name          string
+		spec          string
+		expected      []RequiredOptionsRule
+		expectedError string
+	}{
+		{
+			name:     "Unset",
+			spec:     "",
+			expected: nil,
+		},
+		{
+			name: "Status and profile rules",
+			spec: "Final=on_behalf_of+Signature, *:parent=cover_letter",
+			expected: []RequiredOptionsRule{
+				{Status: "final", Options: []string{RequiredOnBehalfOf, RequiredSignature}},
+				{Status: AnyReportStatus, Profile: "parent", Options: []string{RequiredCoverLetter}},
+			},
+		},
+		{
+			name:          "Missing options",
+			spec:          "final",
+			expectedError: `entry "final" must be status[:profile]=option[+option]`,
+		},
+		{
+			name:          "Missing status",
+			spec:          ":parent=role",
+			expectedError: "has no status",
+		},
+		{
+			name:          "Unknown option",
+			spec:          "final=approver",
+			expectedError: `unknown option "approver"`,
+		},
+		{
+			name:          "Unknown profile",
+			spec:          "final:teacher=role",
+			expectedError: `report profile "teacher" not found`,
+		},
+		{
+			name:          "Duplicate rule",
+			spec:          "final=role,FINAL=watermark",
+			expectedError: "duplicate rule",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &ReportConfig{RequiredOptionsSpec: tt.spec, Profiles: profiles}
+			err := cfg.LoadRequiredOptions()
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg.RequiredOptionsRules)
+		})
+	}
+}
+
+func TestReportConfig_RequiredOptions(t *testing.T) {
+	cfg := &ReportConfig{
+		RequiredOptionsSpec: "final=signature+on_behalf_of,*:parent=cover_letter,draft:parent=watermark",
+		Profiles:            map[string]ReportProfile{"parent": {Format: ReportFormatPDF}},
+	}
+	require.NoError(t, cfg.LoadRequiredOptions())
+
+	tests := []struct {
+		name          string
+		reportContext ReportContext
+		profile       string
+		expected      []string
+	}{
+		{
+			name:     "No status is final",
+			expected: []string{RequiredOnBehalfOf, RequiredSignature},
+		},
+		{
+			name:          "Rules for the status and profile combine",
+			reportContext: ReportContext{Status: "Final"},
+			profile:       "parent",
+			expected:      []string{RequiredOnBehalfOf, RequiredCoverLetter, RequiredSignature},
+		},
+		{
+			name:          "Any status",
+			reportContext: ReportContext{Status: "draft"},
+			profile:       "parent",
+			expected:      []string{RequiredCoverLetter, RequiredWatermark},
+		},
+		{
+			name:          "No matching rule",
+			reportContext: ReportContext{Status: "draft"},
+			profile:       DefaultProfileName,
+			expected:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, cfg.RequiredOptions(tt.reportContext, tt.profile))
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// DisplayLocation returns the zone report times are shown in: Timezone, or
+// the server's local zone when it is unset
+func (c *ReportConfig) DisplayLocation() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("REPORT_TIMEZONE: unknown time zone %q", c.Timezone)
+	}
+	return loc, nil
+}
+
+// UpstreamLocation returns the zone upstream dates sent with a UTC offset
+// take their day in: UpstreamTimezone, or the display zone when it is unset.
+func (c *ReportConfig) UpstreamLocation() (*time.Location, error) {
+	if c.UpstreamTimezone == "" {
+		return c.DisplayLocation()
+	}
+	loc, err := time.LoadLocation(c.UpstreamTimezone)
+	if err != nil {
+		return nil, fmt.Errorf("REPORT_UPSTREAM_TIMEZONE: unknown time zone %q", c.UpstreamTimezone)
+	}
+	return loc, nil
+}
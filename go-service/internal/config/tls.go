@@ -0,0 +1,73 @@
+package config
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+)
+
+// DefaultTLSMinVersion is used when no minimum TLS version is configured
+const DefaultTLSMinVersion = "1.2"
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TLSClientConfig builds the TLS configuration for connections to the Node.js
+// API. Versions are given as "1.0" to "1.3" and cipher suites by their Go
+// names, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. An empty cipher list
+// keeps Go's defaults. Cipher suites can't be chosen for TLS 1.3, so a list
+// that could never be used is rejected along with any other impossible
+// combination.
+func (c *NodeJSConfig) TLSClientConfig() (*tls.Config, error) {
+	minName := c.TLSMinVersion
+	if minName == "" {
+		minName = DefaultTLSMinVersion
+	}
+	minVersion, ok := tlsVersions[minName]
+	if !ok {
+		return nil, fmt.Errorf("NODEJS_TLS_MIN_VERSION must be one of 1.0, 1.1, 1.2 or 1.3, got %q", c.TLSMinVersion)
+	}
+
+	tlsConfig := &tls.Config{MinVersion: minVersion}
+
+	if c.TLSMaxVersion != "" {
+		maxVersion, ok := tlsVersions[c.TLSMaxVersion]
+		if !ok {
+			return nil, fmt.Errorf("NODEJS_TLS_MAX_VERSION must be one of 1.0, 1.1, 1.2 or 1.3, got %q", c.TLSMaxVersion)
+		}
+		if maxVersion < minVersion {
+			return nil, fmt.Errorf("NODEJS_TLS_MIN_VERSION %s is above NODEJS_TLS_MAX_VERSION %s", minName, c.TLSMaxVersion)
+		}
+		tlsConfig.MaxVersion = maxVersion
+	}
+
+	if len(c.TLSCipherSuites) > 0 {
+		if minVersion == tls.VersionTLS13 {
+			return nil, fmt.Errorf("NODEJS_TLS_CIPHER_SUITES can't be used with TLS 1.3 only, whose cipher suites are not configurable")
+		}
+
+		suites := make(map[string]uint16)
+		for _, suite := range tls.CipherSuites() {
+			suites[suite.Name] = suite.ID
+		}
+
+		var unknown []string
+		for _, name := range c.TLSCipherSuites {
+			id, ok := suites[name]
+			if !ok {
+				unknown = append(unknown, name)
+				continue
+			}
+			tlsConfig.CipherSuites = append(tlsConfig.CipherSuites, id)
+		}
+		if len(unknown) > 0 {
+			return nil, fmt.Errorf("NODEJS_TLS_CIPHER_SUITES has unknown or insecure cipher suites: %s", strings.Join(unknown, ", "))
+		}
+	}
+
+	return tlsConfig, nil
+}
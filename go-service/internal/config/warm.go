@@ -0,0 +1,26 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// WarmStudents returns the student IDs in WarmStudentIDs. An entry that is
+// not a positive decimal ID is an error naming the entry.
+func (c *ReportConfig) WarmStudents() ([]int, error) {
+	if strings.TrimSpace(c.WarmStudentIDs) == "" {
+		return nil, nil
+	}
+
+	var ids []int
+	for _, part := range strings.Split(c.WarmStudentIDs, ",") {
+		part = strings.TrimSpace(part)
+		id, err := strconv.Atoi(part)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("REPORT_WARM_STUDENT_IDS: invalid student ID %q", part)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
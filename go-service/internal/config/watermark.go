@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WatermarkRule gives the watermark of reports with Status, requested by
+// Role when it is set or by anyone otherwise. An empty Text means no watermark.
+type WatermarkRule struct {
+	Status string `json:"status"`
+	Role   string `json:"role,omitempty"`
+	Text   string `json:"text"`
+}
+
+// LoadWatermarkRules parses WatermarkRulesSpec, a comma-separated list of
+// status=text or status:role=text entries, into WatermarkRules
+func (c *ReportConfig) LoadWatermarkRules() error {
+	c.WatermarkRules = nil
+	if strings.TrimSpace(c.WatermarkRulesSpec) == "" {
+		return nil
+	}
+
+	seen := make(map[ReportContext]bool)
+	for _, entry := range strings.Split(c.WatermarkRulesSpec, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		selectorSpec, text, ok := strings.Cut(entry, "=")
+		if !ok {
+			return fmt.Errorf("REPORT_WATERMARK_RULES: entry %q must be status[:role]=text", strings.TrimSpace(entry))
+		}
+		status, role, _ := strings.Cut(selectorSpec, ":")
+		if strings.TrimSpace(status) == "" {
+			return fmt.Errorf("REPORT_WATERMARK_RULES: entry %q has no status", strings.TrimSpace(entry))
+		}
+
+		selector := ReportContext{Status: status, Role: role}.normalized()
+		if seen[selector] {
+			return fmt.Errorf("REPORT_WATERMARK_RULES: duplicate rule for %q", strings.TrimSpace(selectorSpec))
+		}
+		seen[selector] = true
+
+		c.WatermarkRules = append(c.WatermarkRules, WatermarkRule{
+			Status: selector.Status,
+			Role:   selector.Role,
+			Text:   strings.TrimSpace(text),
+		})
+	}
+	return nil
+}
+
+// ConditionalWatermark resolves the watermark for a report context. A
+// rule for the status and role wins over a rule for the status alone, and
+// no matching rule means no watermark. ok is false when no rules are
+// configured, leaving the watermark to the profile and REPORT_WATERMARK.
+func (c *ReportConfig) ConditionalWatermark(reportContext ReportContext) (text string, ok bool) {
+	if len(c.WatermarkRules) == 0 {
+		return "", false
+	}
+
+	reportContext = reportContext.normalized()
+	var statusOnly *WatermarkRule
+	for i, rule := range c.WatermarkRules {
+		if !strings.EqualFold(rule.Status, reportContext.Status) {
+			continue
+		}
+		if rule.Role == "" {
+			statusOnly = &c.WatermarkRules[i]
+		} else if strings.EqualFold(rule.Role, reportContext.Role) {
+			return rule.Text, true
+		}
+	}
+
+	if statusOnly != nil {
+		return statusOnly.Text, true
+	}
+	return "", true
+}
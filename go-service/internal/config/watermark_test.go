@@ -0,0 +1,91 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportConfig_LoadWatermarkRules(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          string
+		expected      []WatermarkRule
+		expectedError string
+	}{
+		{
+			name:     "Unset",
+			spec:     "",
+			expected: nil,
+		},
+		{
+			name: "Status and role rules",
+			spec: "Draft=DRAFT, final:Staff=INTERNAL COPY,final=",
+			expected: []WatermarkRule{
+				{Status: "draft", Text: "DRAFT"},
+				{Status: "final", Role: "staff", Text: "INTERNAL COPY"},
+				{Status: "final", Text: ""},
+			},
+		},
+		{
+			name:          "Missing text",
+			spec:          "draft",
+			expectedError: `entry "draft" must be status[:role]=text`,
+		},
+		{
+			name:          "Missing status",
+			spec:          ":staff=INTERNAL",
+			expectedError: "has no status",
+		},
+		{
+			name:          "Duplicate rule",
+			spec:          "draft=DRAFT,DRAFT=PROVISIONAL",
+			expectedError: "duplicate rule",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &ReportConfig{WatermarkRulesSpec: tt.spec}
+			err := cfg.LoadWatermarkRules()
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg.WatermarkRules)
+		})
+	}
+}
+
+func TestReportConfig_ConditionalWatermark(t *testing.T) {
+	cfg := &ReportConfig{WatermarkRulesSpec: "draft=DRAFT,provisional=DRAFT,final:staff=INTERNAL COPY"}
+	require.NoError(t, cfg.LoadWatermarkRules())
+
+	tests := []struct {
+		name          string
+		reportContext ReportContext
+		expected      string
+	}{
+		{name: "Status only", reportContext: ReportContext{Status: "draft"}, expected: "DRAFT"},
+		{name: "Status rule applies to any role", reportContext: ReportContext{Status: "draft", Role: "staff"}, expected: "DRAFT"},
+		{name: "Status and role", reportContext: ReportContext{Status: "FINAL", Role: "Staff"}, expected: "INTERNAL COPY"},
+		{name: "Empty status is final", reportContext: ReportContext{Role: "staff"}, expected: "INTERNAL COPY"},
+		{name: "No matching rule", reportContext: ReportContext{Role: "parent"}, expected: ""},
+		{name: "Unknown status", reportContext: ReportContext{Status: "archived"}, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, ok := cfg.ConditionalWatermark(tt.reportContext)
+			assert.True(t, ok)
+			assert.Equal(t, tt.expected, text)
+		})
+	}
+
+	_, ok := (&ReportConfig{}).ConditionalWatermark(ReportContext{Status: "draft"})
+	assert.False(t, ok, "without rules the watermark is left to the profile")
+}
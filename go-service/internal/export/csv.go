@@ -0,0 +1,141 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"student-report-service/internal/models"
+)
+
+// QuoteMode controls when CSV fields are wrapped in double quotes
+type QuoteMode string
+
+const (
+	// QuoteMinimal quotes only fields that need it (delimiter, quotes, line
+	// breaks, surrounding whitespace) plus empty fields
+	QuoteMinimal QuoteMode = "minimal"
+	// QuoteAll quotes every field
+	QuoteAll QuoteMode = "all"
+)
+
+// ParseQuoteMode converts a configuration value into a QuoteMode
+func ParseQuoteMode(value string) (QuoteMode, error) {
+	switch QuoteMode(strings.ToLower(strings.TrimSpace(value))) {
+	case "", QuoteMinimal:
+		return QuoteMinimal, nil
+	case QuoteAll:
+		return QuoteAll, nil
+	default:
+		return "", fmt.Errorf("unknown CSV quote mode: %q", value)
+	}
+}
+
+// CSVWriter writes RFC 4180 compliant records.
+//
+// Unlike encoding/csv it supports quoting every field, and it always emits
+// empty values as "" so that no record ends with a bare delimiter.
+type CSVWriter struct {
+	w         *bufio.Writer
+	mode      QuoteMode
+	delimiter byte
+}
+
+// NewCSVWriter creates a CSV writer using a comma delimiter and CRLF line endings
+func NewCSVWriter(w io.Writer, mode QuoteMode) *CSVWriter {
+	if mode == "" {
+		mode = QuoteMinimal
+	}
+	return &CSVWriter{
+		w:         bufio.NewWriter(w),
+		mode:      mode,
+		delimiter: ',',
+	}
+}
+
+// Write writes a single record
+func (cw *CSVWriter) Write(record []string) error {
+	for i, field := range record {
+		if i > 0 {
+			if err := cw.w.WriteByte(cw.delimiter); err != nil {
+				return err
+			}
+		}
+
+		if !cw.needsQuotes(field) {
+			if _, err := cw.w.WriteString(field); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := cw.w.WriteByte('"'); err != nil {
+			return err
+		}
+		if _, err := cw.w.WriteString(strings.ReplaceAll(field, `"`, `""`)); err != nil {
+			return err
+		}
+		if err := cw.w.WriteByte('"'); err != nil {
+			return err
+		}
+	}
+
+	_, err := cw.w.WriteString("\r\n")
+	return err
+}
+
+// Flush writes any buffered data to the underlying writer
+func (cw *CSVWriter) Flush() error {
+	return cw.w.Flush()
+}
+
+func (cw *CSVWriter) needsQuotes(field string) bool {
+	if cw.mode == QuoteAll || field == "" {
+		return true
+	}
+	if strings.ContainsAny(field, string(cw.delimiter)+"\"\r\n") {
+		return true
+	}
+	// Preserve leading/trailing whitespace, which many parsers trim otherwise
+	return strings.TrimSpace(field) != field
+}
+
+// StudentCSVHeader is the header row used for student list exports
+var StudentCSVHeader = []string{"id", "name", "email", "systemAccess", "class", "section", "roll"}
+
+// StudentCSVRecord converts a student list item into a CSV record
+func StudentCSVRecord(student models.StudentListItem) []string {
+	roll := ""
+	if student.Roll != nil {
+		roll = strconv.Itoa(*student.Roll)
+	}
+
+	return []string{
+		strconv.Itoa(student.ID),
+		student.Name,
+		student.Email,
+		strconv.FormatBool(student.SystemAccess),
+		models.SafeString(student.Class, ""),
+		models.SafeString(student.Section, ""),
+		roll,
+	}
+}
+
+// WriteStudentsCSV writes the header row followed by one record per student
+func WriteStudentsCSV(w io.Writer, students []models.StudentListItem, mode QuoteMode) error {
+	cw := NewCSVWriter(w, mode)
+
+	if err := cw.Write(StudentCSVHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, student := range students {
+		if err := cw.Write(StudentCSVRecord(student)); err != nil {
+			return fmt.Errorf("failed to write CSV record for student %d: %w", student.ID, err)
+		}
+	}
+
+	return cw.Flush()
+}
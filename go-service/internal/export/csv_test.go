@@ -0,0 +1,137 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVWriter_Write(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     QuoteMode
+		record   []string
+		expected string
+	}{
+		{
+			name:     "Plain values minimal",
+			mode:     QuoteMinimal,
+			record:   []string{"1", "John Doe", "john@example.com"},
+			expected: "1,John Doe,john@example.com\r\n",
+		},
+		{
+			name:     "Plain values quote all",
+			mode:     QuoteAll,
+			record:   []string{"1", "John Doe"},
+			expected: "\"1\",\"John Doe\"\r\n",
+		},
+		{
+			name:     "Empty values are always quoted",
+			mode:     QuoteMinimal,
+			record:   []string{"1", "", ""},
+			expected: "1,\"\",\"\"\r\n",
+		},
+		{
+			name:     "Value containing delimiter",
+			mode:     QuoteMinimal,
+			record:   []string{"Doe, John"},
+			expected: "\"Doe, John\"\r\n",
+		},
+		{
+			name:     "Value containing quotes",
+			mode:     QuoteMinimal,
+			record:   []string{`John "JD" Doe`},
+			expected: "\"John \"\"JD\"\" Doe\"\r\n",
+		},
+		{
+			name:     "Value containing newlines",
+			mode:     QuoteMinimal,
+			record:   []string{"line1\nline2", "a\r\nb"},
+			expected: "\"line1\nline2\",\"a\r\nb\"\r\n",
+		},
+		{
+			name:     "Value with surrounding whitespace",
+			mode:     QuoteMinimal,
+			record:   []string{" padded ", "trailing "},
+			expected: "\" padded \",\"trailing \"\r\n",
+		},
+		{
+			name:     "Only quotes",
+			mode:     QuoteAll,
+			record:   []string{`"`, `""`},
+			expected: "\"\"\"\",\"\"\"\"\"\"\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			cw := NewCSVWriter(&buf, tt.mode)
+
+			require.NoError(t, cw.Write(tt.record))
+			require.NoError(t, cw.Flush())
+
+			assert.Equal(t, tt.expected, buf.String())
+			assert.False(t, strings.HasSuffix(strings.TrimSuffix(buf.String(), "\r\n"), ","))
+
+			// Round-trip through a standard RFC 4180 reader
+			parsed, err := csv.NewReader(strings.NewReader(buf.String())).Read()
+			require.NoError(t, err)
+			expected := make([]string, len(tt.record))
+			for i, field := range tt.record {
+				// encoding/csv normalizes \r\n inside quoted fields to \n
+				expected[i] = strings.ReplaceAll(field, "\r\n", "\n")
+			}
+			assert.Equal(t, expected, parsed)
+		})
+	}
+}
+
+func TestParseQuoteMode(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expected      QuoteMode
+		expectedError bool
+	}{
+		{name: "Empty defaults to minimal", value: "", expected: QuoteMinimal},
+		{name: "Minimal", value: "minimal", expected: QuoteMinimal},
+		{name: "All is case insensitive", value: "ALL", expected: QuoteAll},
+		{name: "Unknown mode", value: "sometimes", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, err := ParseQuoteMode(tt.value)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, mode)
+		})
+	}
+}
+
+func TestWriteStudentsCSV(t *testing.T) {
+	class := "Grade 10"
+	roll := 7
+	students := []models.StudentListItem{
+		{ID: 1, Name: "Doe, John", Email: "john@example.com", SystemAccess: true, Class: &class, Roll: &roll},
+		{ID: 2, Name: "Jane Smith", Email: "jane@example.com"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteStudentsCSV(&buf, students, QuoteMinimal))
+
+	expected := "id,name,email,systemAccess,class,section,roll\r\n" +
+		"1,\"Doe, John\",john@example.com,true,Grade 10,\"\",7\r\n" +
+		"2,Jane Smith,jane@example.com,false,\"\",\"\",\"\"\r\n"
+	assert.Equal(t, expected, buf.String())
+}
@@ -0,0 +1,177 @@
+// Package fixtures generates a reproducible corpus of sample students and
+// their reports for use as golden data in integration tests. It is not used
+// by the service itself.
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+	"student-report-service/internal/pdf"
+)
+
+// GeneratedAt is the generation time recorded in every fixture report
+var GeneratedAt = time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+// StudentsFile is written next to the reports and holds the students they were generated from
+const StudentsFile = "students.json"
+
+// firstStudentID is the ID of the first fixture student
+const firstStudentID = 1001
+
+// emailDomain is reserved for documentation, so fixture addresses never reach anyone
+const emailDomain = "example.com"
+
+// fixtureWatermark marks fixture reports so that they are never mistaken for real ones
+const fixtureWatermark = "Test Fixture - Not Real Student Data"
+
+var (
+	firstNames = []string{"Aarav", "Maya", "Liam", "Zara", "Noah", "Ananya", "Ethan", "Sofia", "Kabir", "Chloe", "Omar", "Isla"}
+	lastNames  = []string{"Sharma", "Okafor", "Nguyen", "Garcia", "Smith", "Haddad", "Kowalski", "Tanaka", "Mensah", "Rossi"}
+	genders    = []string{"Male", "Female", "Other"}
+	sections   = []string{"A", "B", "C", "D"}
+	relations  = []string{"Uncle", "Aunt", "Grandparent", "Family Friend"}
+	streets    = []string{"Maple Avenue", "Station Road", "Riverside Drive", "Hill Street", "Park Lane", "Church Road"}
+	cities     = []string{"Springfield", "Riverton", "Lakeside", "Fairview", "Greenville"}
+	reporters  = []string{"Ms. Patel", "Mr. Johnson", "Mrs. Chen", "Dr. Adeyemi"}
+)
+
+// Students returns count fake students generated from seed. The same seed
+// and count always give the same students.
+func Students(seed int64, count int) []models.Student {
+	rng := rand.New(rand.NewSource(seed))
+
+	students := make([]models.Student, 0, count)
+	for i := 0; i < count; i++ {
+		students = append(students, newStudent(rng, firstStudentID+i))
+	}
+	return students
+}
+
+// newStudent draws one student. Some optional fields are left unset so that
+// fixtures cover missing values too.
+func newStudent(rng *rand.Rand, id int) models.Student {
+	pick := func(values []string) string { return values[rng.Intn(len(values))] }
+	optional := func(value string) *string {
+		if rng.Intn(5) == 0 {
+			return nil
+		}
+		return &value
+	}
+	phone := func() string { return fmt.Sprintf("+1-555-%03d-%04d", rng.Intn(1000), rng.Intn(10000)) }
+	address := func() string {
+		return fmt.Sprintf("%d %s, %s", 1+rng.Intn(400), pick(streets), pick(cities))
+	}
+
+	first, last := pick(firstNames), pick(lastNames)
+	class := 1 + rng.Intn(12)
+	birthYear := 2023 - class - 5
+	roll := 1 + rng.Intn(40)
+
+	student := models.Student{
+		ID:               id,
+		Name:             first + " " + last,
+		Email:            fmt.Sprintf("%s.%s.%d@%s", strings.ToLower(first), strings.ToLower(last), id, emailDomain),
+		SystemAccess:     rng.Intn(2) == 0,
+		Phone:            optional(phone()),
+		Gender:           optional(pick(genders)),
+		DOB:              optional(fmt.Sprintf("%04d-%02d-%02d", birthYear, 1+rng.Intn(12), 1+rng.Intn(28))),
+		Class:            optional(fmt.Sprintf("%d", class)),
+		Section:          optional(pick(sections)),
+		Roll:             &roll,
+		FatherName:       optional(pick(firstNames) + " " + last),
+		FatherPhone:      optional(phone()),
+		MotherName:       optional(pick(firstNames) + " " + last),
+		MotherPhone:      optional(phone()),
+		CurrentAddress:   optional(address()),
+		PermanentAddress: optional(address()),
+		AdmissionDate:    optional(fmt.Sprintf("%04d-%02d-%02d", 2023-class+1, 6+rng.Intn(3), 1+rng.Intn(28))),
+		ReporterName:     optional(pick(reporters)),
+	}
+
+	if rng.Intn(3) == 0 {
+		guardian := pick(firstNames) + " " + pick(lastNames)
+		guardianPhone := phone()
+		relation := pick(relations)
+		student.GuardianName = &guardian
+		student.GuardianPhone = &guardianPhone
+		student.RelationOfGuardian = &relation
+	}
+
+	return student
+}
+
+// Generate writes a PDF and a plain-text report for each of count fake
+// students generated from seed to dir, followed by the students themselves
+// in StudentsFile, and returns the report paths.
+// Reports are rendered by the service's generator with a fixed clock, so
+// re-running with the same seed and count gives byte-identical files.
+// Existing reports in dir are never overwritten.
+func Generate(dir string, seed int64, count int) ([]string, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("invalid fixture count %d: must be at least 1", count)
+	}
+
+	generator, err := pdf.NewGenerator(&config.ReportConfig{
+		OutputDir:     dir,
+		MaxFileSize:   10 * 1024 * 1024,
+		WatermarkText: fixtureWatermark,
+		CollisionMode: config.ReportCollisionFail,
+	})
+	if err != nil {
+		return nil, err
+	}
+	generator.SetClock(func() time.Time { return GeneratedAt })
+
+	students := Students(seed, count)
+	paths := make([]string, 0, 2*len(students))
+	for i := range students {
+		student := &students[i]
+
+		pdfPath, err := generator.GenerateStudentReport(student, fixtureMetadata(student, config.ReportFormatPDF))
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate fixture report for student %d: %w", student.ID, err)
+		}
+
+		textPath, err := generator.GenerateStudentTextReport(student, fixtureMetadata(student, config.ReportFormatText))
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate fixture text report for student %d: %w", student.ID, err)
+		}
+
+		paths = append(paths, pdfPath, textPath)
+	}
+
+	if err := writeStudents(filepath.Join(dir, StudentsFile), students); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// fixtureMetadata returns the metadata of a fixture student's report in format
+func fixtureMetadata(student *models.Student, format string) *models.ReportMetadata {
+	return &models.ReportMetadata{
+		GeneratedAt: GeneratedAt,
+		GeneratedBy: "Fixtures",
+		ReportID:    pdf.StudentReportID(student.ID, GeneratedAt, format),
+	}
+}
+
+// writeStudents saves the fixture students as indented JSON
+func writeStudents(path string, students []models.Student) error {
+	data, err := json.MarshalIndent(students, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture students: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to save fixture students: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"student-report-service/internal/models"
+	"student-report-service/internal/pdf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStudents(t *testing.T) {
+	first := Students(42, 25)
+	second := Students(42, 25)
+
+	require.Len(t, first, 25)
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, Students(43, 25))
+	assert.Equal(t, first[:10], Students(42, 10))
+
+	for i, student := range first {
+		assert.Equal(t, firstStudentID+i, student.ID)
+		assert.NotEmpty(t, student.Name)
+		assert.Contains(t, student.Email, "@"+emailDomain)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("Same seed gives identical files", func(t *testing.T) {
+		dirA, dirB := t.TempDir(), t.TempDir()
+
+		pathsA, err := Generate(dirA, 7, 3)
+		require.NoError(t, err)
+		pathsB, err := Generate(dirB, 7, 3)
+		require.NoError(t, err)
+
+		require.Len(t, pathsA, 6)
+		require.Len(t, pathsB, 6)
+		for i := range pathsA {
+			relA, err := filepath.Rel(dirA, pathsA[i])
+			require.NoError(t, err)
+			relB, err := filepath.Rel(dirB, pathsB[i])
+			require.NoError(t, err)
+			assert.Equal(t, relA, relB)
+			assertSameContent(t, pathsA[i], pathsB[i])
+		}
+		assertSameContent(t, filepath.Join(dirA, StudentsFile), filepath.Join(dirB, StudentsFile))
+	})
+
+	t.Run("Reports hold the fixture students", func(t *testing.T) {
+		dir := t.TempDir()
+		paths, err := Generate(dir, 7, 2)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(filepath.Join(dir, StudentsFile))
+		require.NoError(t, err)
+		var students []models.Student
+		require.NoError(t, json.Unmarshal(data, &students))
+		assert.Equal(t, Students(7, 2), students)
+
+		text, err := pdf.ReportText(paths[0])
+		require.NoError(t, err)
+		assert.Contains(t, text, students[0].Name)
+		assert.Contains(t, text, "Report ID: RPT-1001-1705314600")
+
+		text, err = pdf.ReportText(paths[1])
+		require.NoError(t, err)
+		assert.Contains(t, text, "Report ID: RPT-1001-1705314600-TXT")
+	})
+
+	t.Run("Existing reports are not overwritten", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := Generate(dir, 7, 1)
+		require.NoError(t, err)
+
+		_, err = Generate(dir, 7, 1)
+		assert.ErrorIs(t, err, pdf.ErrReportExists)
+	})
+
+	t.Run("Invalid count", func(t *testing.T) {
+		_, err := Generate(t.TempDir(), 7, 0)
+		assert.ErrorContains(t, err, "invalid fixture count")
+	})
+}
+
+// assertSameContent checks that two files are byte-identical
+func assertSameContent(t *testing.T, pathA, pathB string) {
+	t.Helper()
+
+	a, err := os.ReadFile(pathA)
+	require.NoError(t, err)
+	b, err := os.ReadFile(pathB)
+	require.NoError(t, err)
+	assert.Equal(t, a, b, filepath.Base(pathA))
+}
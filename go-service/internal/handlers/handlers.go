@@ -2,11 +2,16 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+	"student-report-service/internal/pdf"
+	"student-report-service/internal/redact"
 	"student-report-service/internal/service"
 
 	"github.com/gorilla/mux"
@@ -14,7 +19,9 @@ import (
 
 // ReportHandler handles HTTP requests for report generation
 type ReportHandler struct {
-	reportService *service.ReportService
+	reportService      *service.ReportService
+	redactor           *redact.Redactor
+	streamWriteTimeout time.Duration
 }
 
 // NewReportHandler creates a new report handler
@@ -24,6 +31,18 @@ func NewReportHandler(reportService *service.ReportService) *ReportHandler {
 	}
 }
 
+// SetRedactor scrubs personal data from error messages returned to clients
+func (h *ReportHandler) SetRedactor(redactor *redact.Redactor) {
+	h.redactor = redactor
+}
+
+// SetStreamWriteTimeout gives streamed responses this long for every chunk
+// they write, instead of the server's write timeout for the whole response.
+// Zero keeps the server's write timeout.
+func (h *ReportHandler) SetStreamWriteTimeout(timeout time.Duration) {
+	h.streamWriteTimeout = timeout
+}
+
 // GenerateReport handles POST /api/v1/reports/student/{id}
 func (h *ReportHandler) GenerateReport(w http.ResponseWriter, r *http.Request) {
 	// Extract student ID from URL
@@ -46,13 +65,23 @@ func (h *ReportHandler) GenerateReport(w http.ResponseWriter, r *http.Request) {
 		generatedBy = "API"
 	}
 
-	// Generate the report
-	result, err := h.reportService.GenerateStudentReport(studentID, generatedBy)
+	if err := h.reportService.CheckGeneratedBy(generatedBy); err != nil {
+		h.writeErrorResponse(w, http.StatusForbidden, "generated_by is not allowed", err)
+		return
+	}
+
+	// Generate the report using the requested profile (empty means default)
+	result, err := h.reportService.GenerateStudentReportWithProfile(r.Context(), studentID, reportOptions(r, generatedBy))
 	if err != nil {
 		statusCode := http.StatusInternalServerError
+		var validationErr *service.ValidationError
 
 		// Check if it's a client error (student not found, etc.)
-		if isClientError(err) {
+		if errors.Is(err, service.ErrReadOnly) || errors.Is(err, service.ErrDisabledInSafeMode) {
+			statusCode = http.StatusForbidden
+		} else if errors.As(err, &validationErr) {
+			statusCode = http.StatusBadRequest
+		} else if isClientError(err) {
 			statusCode = http.StatusNotFound
 		}
 
@@ -64,6 +93,179 @@ func (h *ReportHandler) GenerateReport(w http.ResponseWriter, r *http.Request) {
 	h.writeSuccessResponse(w, http.StatusCreated, "Report generated successfully", result)
 }
 
+// GenerateReportFromStudent handles POST /api/v1/reports/student. The body is
+// the student data to render, so no call to the Node.js API is made; this is
+// how reports are generated in safe mode.
+func (h *ReportHandler) GenerateReportFromStudent(w http.ResponseWriter, r *http.Request) {
+	var student models.Student
+	if err := json.NewDecoder(r.Body).Decode(&student); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	if student.ID <= 0 {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid student ID format", nil)
+		return
+	}
+
+	generatedBy := r.URL.Query().Get("generated_by")
+	if generatedBy == "" {
+		generatedBy = "API"
+	}
+
+	if err := h.reportService.CheckGeneratedBy(generatedBy); err != nil {
+		h.writeErrorResponse(w, http.StatusForbidden, "generated_by is not allowed", err)
+		return
+	}
+
+	result, err := h.reportService.GenerateReportFromStudent(r.Context(), &student, reportOptions(r, generatedBy))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		var validationErr *service.ValidationError
+
+		if errors.Is(err, service.ErrReadOnly) || errors.Is(err, service.ErrDisabledInSafeMode) {
+			statusCode = http.StatusForbidden
+		} else if errors.As(err, &validationErr) || isClientError(err) {
+			statusCode = http.StatusBadRequest
+		}
+
+		h.writeErrorResponse(w, statusCode, "Failed to generate report", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusCreated, "Report generated successfully", result)
+}
+
+// reportTextResponse is the data of GET /api/v1/reports/{reportID}/text
+type reportTextResponse struct {
+	ReportID string `json:"report_id"`
+	Text     string `json:"text"`
+}
+
+// GetReportText handles GET /api/v1/reports/{reportID}/text
+func (h *ReportHandler) GetReportText(w http.ResponseWriter, r *http.Request) {
+	reportID := mux.Vars(r)["reportID"]
+
+	text, err := h.reportService.ExtractReportText(reportID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+
+		if isClientError(err) {
+			statusCode = http.StatusNotFound
+		}
+
+		h.writeErrorResponse(w, statusCode, "Failed to extract report text", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, "Report text extracted successfully", reportTextResponse{ReportID: reportID, Text: text})
+}
+
+// reportVerificationResponse is the data of GET /api/v1/reports/{reportID}/verify
+type reportVerificationResponse struct {
+	ReportID string `json:"report_id"`
+	Verified bool   `json:"verified"`
+}
+
+// VerifyReport handles GET /api/v1/reports/{reportID}/verify
+func (h *ReportHandler) VerifyReport(w http.ResponseWriter, r *http.Request) {
+	reportID := mux.Vars(r)["reportID"]
+
+	if err := h.reportService.VerifyReport(reportID); err != nil {
+		statusCode := http.StatusInternalServerError
+
+		if errors.Is(err, pdf.ErrVerificationFailed) {
+			statusCode = http.StatusUnprocessableEntity
+		} else if errors.Is(err, pdf.ErrVerificationDisabled) {
+			statusCode = http.StatusConflict
+		} else if isClientError(err) {
+			statusCode = http.StatusNotFound
+		}
+
+		h.writeErrorResponse(w, statusCode, "Failed to verify report", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, "Report verified successfully", reportVerificationResponse{ReportID: reportID, Verified: true})
+}
+
+// reportIndexResponse is the data of POST /api/v1/reports/index/rebuild
+type reportIndexResponse struct {
+	Reports int `json:"reports"`
+}
+
+// RebuildReportIndex handles POST /api/v1/reports/index/rebuild
+func (h *ReportHandler) RebuildReportIndex(w http.ResponseWriter, r *http.Request) {
+	count, err := h.reportService.RebuildReportIndex()
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, pdf.ErrIndexDisabled) {
+			statusCode = http.StatusConflict
+		}
+		h.writeErrorResponse(w, statusCode, "Failed to rebuild report index", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, "Report index rebuilt successfully", reportIndexResponse{Reports: count})
+}
+
+// householdReportRequest is the body of POST /api/v1/reports/household
+type householdReportRequest struct {
+	StudentIDs []int `json:"student_ids"`
+}
+
+// GenerateHouseholdReport handles POST /api/v1/reports/household
+func (h *ReportHandler) GenerateHouseholdReport(w http.ResponseWriter, r *http.Request) {
+	var req householdReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	if len(req.StudentIDs) == 0 {
+		h.writeErrorResponse(w, http.StatusBadRequest, "At least one student ID is required", nil)
+		return
+	}
+
+	generatedBy := r.URL.Query().Get("generated_by")
+	if generatedBy == "" {
+		generatedBy = "API"
+	}
+
+	if err := h.reportService.CheckGeneratedBy(generatedBy); err != nil {
+		h.writeErrorResponse(w, http.StatusForbidden, "generated_by is not allowed", err)
+		return
+	}
+
+	// Household reports always use the default profile, so no profile is read
+	result, err := h.reportService.GenerateHouseholdReport(req.StudentIDs, service.ReportOptions{
+		GeneratedBy:   generatedBy,
+		OnBehalfOf:    r.URL.Query().Get("on_behalf_of"),
+		ReportContext: requestContext(r),
+	})
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+
+		var validationErr *service.ValidationError
+		if errors.Is(err, service.ErrReadOnly) || errors.Is(err, service.ErrDisabledInSafeMode) {
+			statusCode = http.StatusForbidden
+		} else if errors.As(err, &validationErr) {
+			statusCode = http.StatusBadRequest
+		} else if isClientError(err) {
+			statusCode = http.StatusNotFound
+		}
+
+		h.writeErrorResponse(w, statusCode, "Failed to generate household report", err)
+		return
+	}
+
+	for i := range result.Failures {
+		result.Failures[i].Reason = h.redactor.String(result.Failures[i].Reason)
+	}
+
+	h.writeSuccessResponse(w, http.StatusCreated, "Household report generated successfully", result)
+}
+
 // HealthCheck handles GET /health
 func (h *ReportHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	status := h.reportService.HealthCheck()
@@ -73,20 +275,30 @@ func (h *ReportHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 		statusCode = http.StatusServiceUnavailable
 	}
 
+	// Health is checked on every request and not cached, so proxies must not cache it either
+	for key, values := range service.HealthCacheHeaders(0, 0, time.Now()) {
+		w.Header()[key] = values
+	}
+
 	h.writeResponse(w, statusCode, status)
 }
 
 // CleanupReports handles POST /api/v1/reports/cleanup
 func (h *ReportHandler) CleanupReports(w http.ResponseWriter, r *http.Request) {
-	err := h.reportService.CleanupOldReports()
+	summary, err := h.reportService.CleanupOldReports(r.Context())
 	if err != nil {
-		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to cleanup reports", err)
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, service.ErrReadOnly) || errors.Is(err, service.ErrDisabledInSafeMode) {
+			statusCode = http.StatusForbidden
+		}
+		h.writeErrorResponse(w, statusCode, "Failed to cleanup reports", err)
 		return
 	}
 
 	response := map[string]interface{}{
 		"success":   true,
 		"message":   "Old reports cleaned up successfully",
+		"data":      summary,
 		"timestamp": time.Now(),
 	}
 
@@ -96,21 +308,7 @@ func (h *ReportHandler) CleanupReports(w http.ResponseWriter, r *http.Request) {
 // GetStudents handles GET /api/v1/students
 func (h *ReportHandler) GetStudents(w http.ResponseWriter, r *http.Request) {
 	// Extract query parameters for filtering
-	filters := make(map[string]string)
-
-	// Common filter parameters based on the Node.js API
-	if name := r.URL.Query().Get("name"); name != "" {
-		filters["name"] = name
-	}
-	if className := r.URL.Query().Get("className"); className != "" {
-		filters["className"] = className
-	}
-	if section := r.URL.Query().Get("section"); section != "" {
-		filters["section"] = section
-	}
-	if roll := r.URL.Query().Get("roll"); roll != "" {
-		filters["roll"] = roll
-	}
+	filters := studentFilters(r)
 
 	// Fetch students from the service
 	students, err := h.reportService.GetAllStudents(filters)
@@ -118,7 +316,11 @@ func (h *ReportHandler) GetStudents(w http.ResponseWriter, r *http.Request) {
 		statusCode := http.StatusInternalServerError
 
 		// Check if it's a client error (no students found, etc.)
-		if isClientError(err) {
+		if errors.Is(err, service.ErrFilterNotAllowed) {
+			statusCode = http.StatusBadRequest
+		} else if errors.Is(err, service.ErrDisabledInSafeMode) {
+			statusCode = http.StatusForbidden
+		} else if isClientError(err) {
 			statusCode = http.StatusNotFound
 		}
 
@@ -130,6 +332,92 @@ func (h *ReportHandler) GetStudents(w http.ResponseWriter, r *http.Request) {
 	h.writeSuccessResponse(w, http.StatusOK, "Students retrieved successfully", students)
 }
 
+// ExportStudentsCSV handles GET /api/v1/students/export.
+// Rows are streamed as they arrive from the Node.js API; errors are only
+// reported as JSON if they occur before any CSV has been sent.
+func (h *ReportHandler) ExportStudentsCSV(w http.ResponseWriter, r *http.Request) {
+	csvWriter := &csvResponseWriter{w: w, controller: http.NewResponseController(w), chunkTimeout: h.streamWriteTimeout}
+	if err := h.reportService.StreamAllStudentsCSV(r.Context(), csvWriter, studentFilters(r)); err != nil {
+		if csvWriter.started {
+			// Headers are already sent; the truncated body is all we can do
+			return
+		}
+
+		statusCode := http.StatusInternalServerError
+
+		if errors.Is(err, service.ErrFilterNotAllowed) {
+			statusCode = http.StatusBadRequest
+		} else if errors.Is(err, service.ErrDisabledInSafeMode) {
+			statusCode = http.StatusForbidden
+		} else if isClientError(err) {
+			statusCode = http.StatusNotFound
+		}
+
+		h.writeErrorResponse(w, statusCode, "Failed to export students", err)
+		return
+	}
+
+	csvWriter.start()
+}
+
+// csvResponseWriter sends the CSV response headers on the first write, so an
+// export that fails before producing any output can still return an error.
+// Every write is flushed to the client and, when chunkTimeout is set, moves
+// the write deadline so a long export isn't cut off by the server's timeout.
+type csvResponseWriter struct {
+	w            http.ResponseWriter
+	controller   *http.ResponseController
+	chunkTimeout time.Duration
+	started      bool
+}
+
+func (cw *csvResponseWriter) start() {
+	if cw.started {
+		return
+	}
+	cw.started = true
+
+	cw.w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	cw.w.Header().Set("Content-Disposition", `attachment; filename="students.csv"`)
+	cw.w.WriteHeader(http.StatusOK)
+}
+
+func (cw *csvResponseWriter) Write(p []byte) (int, error) {
+	if cw.chunkTimeout > 0 {
+		// Not every ResponseWriter supports deadlines; the server's timeout applies then
+		_ = cw.controller.SetWriteDeadline(time.Now().Add(cw.chunkTimeout))
+	}
+
+	cw.start()
+	n, err := cw.w.Write(p)
+	if err != nil {
+		return n, err
+	}
+	_ = cw.controller.Flush()
+	return n, nil
+}
+
+// studentFilters extracts the student list filters supported by the Node.js API
+func studentFilters(r *http.Request) map[string]string {
+	filters := make(map[string]string)
+
+	// Common filter parameters based on the Node.js API
+	if name := r.URL.Query().Get("name"); name != "" {
+		filters["name"] = name
+	}
+	if className := r.URL.Query().Get("className"); className != "" {
+		filters["className"] = className
+	}
+	if section := r.URL.Query().Get("section"); section != "" {
+		filters["section"] = section
+	}
+	if roll := r.URL.Query().Get("roll"); roll != "" {
+		filters["roll"] = roll
+	}
+
+	return filters
+}
+
 // Helper methods for consistent response formatting
 
 func (h *ReportHandler) writeSuccessResponse(w http.ResponseWriter, statusCode int, message string, data interface{}) {
@@ -150,7 +438,7 @@ func (h *ReportHandler) writeErrorResponse(w http.ResponseWriter, statusCode int
 	}
 
 	if err != nil {
-		response.Error = err.Error()
+		response.Error = h.redactor.String(err.Error())
 	}
 
 	h.writeResponse(w, statusCode, response)
@@ -166,6 +454,27 @@ func (h *ReportHandler) writeResponse(w http.ResponseWriter, statusCode int, dat
 	}
 }
 
+// reportOptions reads the report options of a student report request: the
+// optional on_behalf_of, which records who the report was generated for,
+// profile and report context
+func reportOptions(r *http.Request, generatedBy string) service.ReportOptions {
+	return service.ReportOptions{
+		GeneratedBy:   generatedBy,
+		OnBehalfOf:    r.URL.Query().Get("on_behalf_of"),
+		Profile:       r.URL.Query().Get("profile"),
+		ReportContext: requestContext(r),
+	}
+}
+
+// requestContext reads the report context from the optional status and
+// role query parameters
+func requestContext(r *http.Request) config.ReportContext {
+	return config.ReportContext{
+		Status: r.URL.Query().Get("status"),
+		Role:   r.URL.Query().Get("role"),
+	}
+}
+
 // Helper function to determine if error is a client error
 func isClientError(err error) bool {
 	errorStr := err.Error()
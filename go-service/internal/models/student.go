@@ -45,7 +45,57 @@ type ErrorResponse struct {
 type ReportMetadata struct {
 	GeneratedAt time.Time `json:"generated_at"`
 	GeneratedBy string    `json:"generated_by"`
-	ReportID    string    `json:"report_id"`
+	// OnBehalfOf names the person a report was generated for, when it differs from GeneratedBy
+	OnBehalfOf string `json:"on_behalf_of,omitempty"`
+	ReportID   string `json:"report_id"`
+
+	// Watermark overrides the configured watermark text when set
+	Watermark *string `json:"-"`
+
+	// ShowPhoto reserves a photo area; a placeholder is drawn when Photo is nil
+	ShowPhoto bool          `json:"-"`
+	Photo     *StudentPhoto `json:"-"`
+
+	// ExtraFields are rendered in an "Additional Information" section, in order
+	ExtraFields []ExtraField `json:"-"`
+
+	// CoverLetter is rendered as a page of its own before the report when set
+	CoverLetter string `json:"-"`
+
+	// Truncated is set by the generator when the report was cut short at the page limit
+	Truncated bool `json:"-"`
+
+	// Unsigned skips the verification record, even with a verification secret configured
+	Unsigned bool `json:"-"`
+}
+
+// ExtraField is a labelled value contributed by an enricher
+type ExtraField struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// AddExtraField appends a labelled value to the report
+func (m *ReportMetadata) AddExtraField(label, value string) {
+	m.ExtraFields = append(m.ExtraFields, ExtraField{Label: label, Value: value})
+}
+
+// StudentPhoto is an image fetched from the student photo service
+type StudentPhoto struct {
+	Data        []byte
+	ContentType string
+}
+
+// HouseholdMember is one sibling's section of a household report
+type HouseholdMember struct {
+	Student  *Student
+	Metadata *ReportMetadata
+}
+
+// HouseholdFailure records a sibling whose section could not be produced
+type HouseholdFailure struct {
+	StudentID int    `json:"student_id"`
+	Reason    string `json:"reason"`
 }
 
 // StudentListResponse represents the response for listing students
@@ -0,0 +1,126 @@
+package pdf
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// defaultCleanupWorkers is used when no worker count is configured
+const defaultCleanupWorkers = 4
+
+// CleanupSummary reports the outcome of a cleanup run
+type CleanupSummary struct {
+	// Deleted counts reports older than the cleanup age that were removed
+	Deleted int `json:"deleted"`
+	// Skipped counts reports kept because they are not old enough yet
+	Skipped int `json:"skipped"`
+	// Failed counts old reports that could not be removed
+	Failed int `json:"failed"`
+}
+
+// CleanupOldReports removes old report files based on configuration. Files
+// are removed by a bounded pool of workers. A file that can't be read or
+// removed is counted as failed without stopping the run, and one removed
+// while the run is in progress is ignored. If ctx is cancelled the summary
+// covers the files handled so far.
+func (g *Generator) CleanupOldReports(ctx context.Context) (*CleanupSummary, error) {
+	summary := &CleanupSummary{}
+	if !g.config.Cleanup {
+		return summary, nil
+	}
+
+	workers := g.config.CleanupWorkers
+	if workers < 1 {
+		workers = defaultCleanupWorkers
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	paths := make(chan string)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range paths {
+				err := g.remove(path)
+				if err == nil {
+					g.unindexReport(path)
+					g.removeVerificationRecord(path)
+				}
+
+				mu.Lock()
+				if err != nil {
+					summary.Failed++
+				} else {
+					summary.Deleted++
+				}
+				mu.Unlock()
+
+				if err != nil && g.logger != nil {
+					g.logger.WithError(err).WithField("path", path).Warn("Failed to remove old report")
+				}
+			}
+		}()
+	}
+
+	err := filepath.WalkDir(g.outputDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if entry.IsDir() || !isReportFile(entry.Name()) {
+			return nil
+		}
+
+		info, err := g.fileInfo(entry)
+		if errors.Is(err, fs.ErrNotExist) {
+			// Removed since the directory was read
+			return nil
+		}
+		if err != nil {
+			mu.Lock()
+			summary.Failed++
+			mu.Unlock()
+			if g.logger != nil {
+				g.logger.WithError(err).WithField("path", path).Warn("Failed to read old report")
+			}
+			return nil
+		}
+		if time.Since(info.ModTime()) <= g.config.CleanupAfter {
+			mu.Lock()
+			summary.Skipped++
+			mu.Unlock()
+			return nil
+		}
+
+		select {
+		case paths <- path:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
+	close(paths)
+	wg.Wait()
+
+	return summary, err
+}
+
+// removeVerificationRecord removes the verification record of a deleted
+// report, if it has one
+func (g *Generator) removeVerificationRecord(reportPath string) {
+	err := g.remove(VerificationRecordPath(reportPath))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) && g.logger != nil {
+		g.logger.WithError(err).WithField("path", reportPath).Warn("Failed to remove verification record")
+	}
+}
@@ -0,0 +1,145 @@
+package pdf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"student-report-service/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeAgedReports creates count PDF files in dir with the given age
+func writeAgedReports(t *testing.T, dir, prefix string, count int, age time.Duration) {
+	t.Helper()
+	modTime := time.Now().Add(-age)
+	for i := 0; i < count; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("%s_%d.pdf", prefix, i))
+		require.NoError(t, os.WriteFile(path, []byte("report"), 0644))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+}
+
+func TestGenerator_CleanupOldReports(t *testing.T) {
+	t.Run("Counts mixed success and failure", func(t *testing.T) {
+		dir := t.TempDir()
+		writeAgedReports(t, dir, "old", 20, 48*time.Hour)
+		writeAgedReports(t, dir, "locked", 5, 48*time.Hour)
+		writeAgedReports(t, dir, "recent", 3, time.Minute)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("keep"), 0644))
+		textReport := filepath.Join(dir, "student_report_1_John_Doe_20240115_103000.txt")
+		require.NoError(t, os.WriteFile(textReport, []byte("report"), 0644))
+		oldTime := time.Now().Add(-48 * time.Hour)
+		require.NoError(t, os.Chtimes(textReport, oldTime, oldTime))
+
+		generator, err := NewGenerator(&config.ReportConfig{
+			OutputDir:      dir,
+			Cleanup:        true,
+			CleanupAfter:   24 * time.Hour,
+			CleanupWorkers: 3,
+		})
+		require.NoError(t, err)
+		generator.remove = func(path string) error {
+			if strings.HasPrefix(filepath.Base(path), "locked") {
+				return errors.New("permission denied")
+			}
+			return os.Remove(path)
+		}
+
+		summary, err := generator.CleanupOldReports(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, &CleanupSummary{Deleted: 21, Skipped: 3, Failed: 5}, summary)
+		assert.NoFileExists(t, textReport)
+
+		remaining, err := filepath.Glob(filepath.Join(dir, "*"))
+		require.NoError(t, err)
+		assert.Len(t, remaining, 9)
+	})
+
+	t.Run("Continues past files that can't be read", func(t *testing.T) {
+		dir := t.TempDir()
+		writeAgedReports(t, dir, "old", 4, 48*time.Hour)
+		writeAgedReports(t, dir, "gone", 2, 48*time.Hour)
+		writeAgedReports(t, dir, "unreadable", 3, 48*time.Hour)
+
+		generator, err := NewGenerator(&config.ReportConfig{
+			OutputDir:    dir,
+			Cleanup:      true,
+			CleanupAfter: 24 * time.Hour,
+		})
+		require.NoError(t, err)
+		generator.fileInfo = func(entry fs.DirEntry) (fs.FileInfo, error) {
+			switch {
+			case strings.HasPrefix(entry.Name(), "gone"):
+				return nil, fs.ErrNotExist
+			case strings.HasPrefix(entry.Name(), "unreadable"):
+				return nil, errors.New("input/output error")
+			}
+			return entry.Info()
+		}
+
+		summary, err := generator.CleanupOldReports(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, &CleanupSummary{Deleted: 4, Failed: 3}, summary)
+
+		remaining, err := filepath.Glob(filepath.Join(dir, "*.pdf"))
+		require.NoError(t, err)
+		assert.Len(t, remaining, 5)
+	})
+
+	t.Run("Disabled cleanup removes nothing", func(t *testing.T) {
+		dir := t.TempDir()
+		writeAgedReports(t, dir, "old", 2, 48*time.Hour)
+
+		generator, err := NewGenerator(&config.ReportConfig{OutputDir: dir, CleanupAfter: time.Hour})
+		require.NoError(t, err)
+
+		summary, err := generator.CleanupOldReports(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, &CleanupSummary{}, summary)
+
+		remaining, err := filepath.Glob(filepath.Join(dir, "*.pdf"))
+		require.NoError(t, err)
+		assert.Len(t, remaining, 2)
+	})
+
+	t.Run("Stops when the context is cancelled", func(t *testing.T) {
+		dir := t.TempDir()
+		writeAgedReports(t, dir, "old", 10, 48*time.Hour)
+
+		generator, err := NewGenerator(&config.ReportConfig{
+			OutputDir:      dir,
+			Cleanup:        true,
+			CleanupAfter:   time.Hour,
+			CleanupWorkers: 1,
+		})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		generator.remove = func(path string) error {
+			cancel()
+			return os.Remove(path)
+		}
+
+		summary, err := generator.CleanupOldReports(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, summary.Deleted, 10)
+		assert.Equal(t, 0, summary.Failed)
+
+		remaining, err := filepath.Glob(filepath.Join(dir, "*.pdf"))
+		require.NoError(t, err)
+		assert.Len(t, remaining, 10-summary.Deleted)
+	})
+}
@@ -0,0 +1,80 @@
+package pdf
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrReportExists is returned when a report file already exists under the
+// name a new report would be saved as
+var ErrReportExists = errors.New("report already exists")
+
+// maxCollisionAttempts bounds how many report IDs are tried in regenerate mode
+const maxCollisionAttempts = 10
+
+// writeReport renders a document and saves it as filename. Report files are
+// named after the student and second they were generated in, as are report
+// IDs, so an existing file means the report ID is already taken. Existing
+// reports are never overwritten: depending on the collision mode the report
+// fails, or gets a suffixed report ID and file name and is rendered again.
+func (g *Generator) writeReport(filename string, metadata *models.ReportMetadata, render func() (*gofpdf.Fpdf, error)) (string, error) {
+	return g.writeReportFile(filename, metadata, func(filename string) (string, error) {
+		pdf, err := render()
+		if err != nil {
+			return "", err
+		}
+		return g.savePDF(pdf, filename)
+	})
+}
+
+// writeReportFile applies the collision handling of writeReport to any
+// report format. save renders the report with metadata's current report ID
+// and saves it as filename.
+func (g *Generator) writeReportFile(filename string, metadata *models.ReportMetadata, save func(filename string) (string, error)) (string, error) {
+	ext := filepath.Ext(filename)
+	baseName := strings.TrimSuffix(filename, ext)
+	baseID := metadata.ReportID
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			metadata.ReportID = fmt.Sprintf("%s-%d", baseID, attempt)
+			filename = fmt.Sprintf("%s_%d%s", baseName, attempt, ext)
+		}
+
+		path, err := save(filename)
+		if err == nil && g.config.VerificationSecret != "" && !metadata.Unsigned {
+			if err = g.writeVerificationRecord(path, metadata.ReportID); err != nil {
+				os.Remove(path)
+				return "", err
+			}
+		}
+		if err == nil {
+			g.indexReport(metadata.ReportID, path)
+		}
+		if !errors.Is(err, ErrReportExists) {
+			return path, err
+		}
+
+		regenerate := g.config.CollisionMode != config.ReportCollisionFail && attempt < maxCollisionAttempts
+		if g.logger != nil {
+			g.logger.WithFields(logrus.Fields{
+				"report_id":  metadata.ReportID,
+				"filename":   filename,
+				"regenerate": regenerate,
+			}).Warn("Report already exists")
+		}
+
+		if !regenerate {
+			return "", err
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package pdf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_ReportCollision(t *testing.T) {
+	student := &models.Student{ID: 1, Name: "John Doe"}
+	existing := []byte("existing report")
+
+	tests := []struct {
+		name             string
+		mode             string
+		existingFiles    []string
+		expectedError    bool
+		expectedFile     string
+		expectedReportID string
+	}{
+		{
+			name:             "No collision",
+			existingFiles:    nil,
+			expectedFile:     "report.pdf",
+			expectedReportID: "RPT-1",
+		},
+		{
+			name:             "Collision regenerates ID by default",
+			existingFiles:    []string{"report.pdf"},
+			expectedFile:     "report_2.pdf",
+			expectedReportID: "RPT-1-2",
+		},
+		{
+			name:             "Regenerated ID can collide too",
+			mode:             config.ReportCollisionRegenerate,
+			existingFiles:    []string{"report.pdf", "report_2.pdf"},
+			expectedFile:     "report_3.pdf",
+			expectedReportID: "RPT-1-3",
+		},
+		{
+			name:          "Collision fails in fail mode",
+			mode:          config.ReportCollisionFail,
+			existingFiles: []string{"report.pdf"},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputDir := t.TempDir()
+			generator, err := NewGenerator(&config.ReportConfig{
+				OutputDir:     outputDir,
+				MaxFileSize:   10 * 1024 * 1024,
+				CollisionMode: tt.mode,
+			})
+			require.NoError(t, err)
+
+			for _, name := range tt.existingFiles {
+				require.NoError(t, os.WriteFile(filepath.Join(outputDir, name), existing, 0644))
+			}
+
+			metadata := testMetadata()
+			path, err := generator.writeReport("report.pdf", metadata, func() (*gofpdf.Fpdf, error) {
+				pdf := generator.newDocument()
+				generator.addStudentReport(pdf, student, metadata)
+				return pdf, nil
+			})
+
+			for _, name := range tt.existingFiles {
+				data, readErr := os.ReadFile(filepath.Join(outputDir, name))
+				require.NoError(t, readErr)
+				assert.Equal(t, existing, data, "existing report %s must not be overwritten", name)
+			}
+
+			if tt.expectedError {
+				assert.ErrorIs(t, err, ErrReportExists)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(outputDir, tt.expectedFile), path)
+			assert.Equal(t, tt.expectedReportID, metadata.ReportID)
+
+			lines, err := ExtractTextLines(path)
+			require.NoError(t, err)
+			assert.Contains(t, lines, "Report ID: "+tt.expectedReportID)
+		})
+	}
+}
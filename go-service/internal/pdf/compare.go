@@ -0,0 +1,120 @@
+package pdf
+
+import (
+	"strings"
+)
+
+// ReportDiff is a textual comparison of two generated reports
+type ReportDiff struct {
+	Identical bool          `json:"identical"`
+	Added     []string      `json:"added,omitempty"`
+	Removed   []string      `json:"removed,omitempty"`
+	Changed   []FieldChange `json:"changed,omitempty"`
+}
+
+// FieldChange describes a "Label: value" line whose value differs between reports
+type FieldChange struct {
+	Field    string `json:"field"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+// CompareReports compares the text content of two reports line by line.
+//
+// Reports are compared through their non-empty text lines, as returned by
+// reportLines. Lines of the form "Label: value" that differ only in value
+// are reported as changed fields rather than as a removal plus an addition.
+func CompareReports(pathA, pathB string) (*ReportDiff, error) {
+	linesA, err := reportLines(pathA)
+	if err != nil {
+		return nil, err
+	}
+
+	linesB, err := reportLines(pathB)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffLines(linesA, linesB), nil
+}
+
+// diffLines computes a longest-common-subsequence diff and pairs up changed fields
+func diffLines(a, b []string) *ReportDiff {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var removed, added []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			removed = append(removed, a[i])
+			i++
+		default:
+			added = append(added, b[j])
+			j++
+		}
+	}
+	removed = append(removed, a[i:]...)
+	added = append(added, b[j:]...)
+
+	diff := &ReportDiff{}
+
+	// Pair removed and added lines that share a field label
+	addedByField := make(map[string][]int)
+	for idx, line := range added {
+		if field, _, ok := splitField(line); ok {
+			addedByField[field] = append(addedByField[field], idx)
+		}
+	}
+
+	matchedAdded := make(map[int]bool)
+	for _, line := range removed {
+		field, oldValue, ok := splitField(line)
+		if ok && len(addedByField[field]) > 0 {
+			idx := addedByField[field][0]
+			addedByField[field] = addedByField[field][1:]
+			matchedAdded[idx] = true
+
+			_, newValue, _ := splitField(added[idx])
+			diff.Changed = append(diff.Changed, FieldChange{Field: field, OldValue: oldValue, NewValue: newValue})
+			continue
+		}
+		diff.Removed = append(diff.Removed, line)
+	}
+
+	for idx, line := range added {
+		if !matchedAdded[idx] {
+			diff.Added = append(diff.Added, line)
+		}
+	}
+
+	diff.Identical = len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0
+	return diff
+}
+
+// splitField splits a "Label: value" line
+func splitField(line string) (field, value string, ok bool) {
+	idx := strings.Index(line, ":")
+	if idx <= 0 {
+		return "", "", false
+	}
+	return strings.TrimSpace(line[:idx]), strings.TrimSpace(line[idx+1:]), true
+}
@@ -0,0 +1,102 @@
+package pdf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generateTestReport renders a report for student into its own temporary directory
+func generateTestReport(t *testing.T, student *models.Student) string {
+	t.Helper()
+
+	generator, err := NewGenerator(&config.ReportConfig{
+		OutputDir:     t.TempDir(),
+		MaxFileSize:   10 * 1024 * 1024,
+		WatermarkText: "Confidential",
+	})
+	require.NoError(t, err)
+
+	path, err := generator.GenerateStudentReport(student, &models.ReportMetadata{
+		GeneratedAt: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+		GeneratedBy: "Test",
+		ReportID:    "RPT-1",
+	})
+	require.NoError(t, err)
+
+	return path
+}
+
+func TestExtractTextLines(t *testing.T) {
+	path := generateTestReport(t, &models.Student{ID: 1, Name: "John (JD) Doe", Email: "john@example.com"})
+
+	lines, err := ExtractTextLines(path)
+	require.NoError(t, err)
+
+	assert.Contains(t, lines, "Student Information Report")
+	assert.Contains(t, lines, "Report ID: RPT-1")
+	assert.Contains(t, lines, "Full Name: John (JD) Doe")
+	assert.Contains(t, lines, "Email Address: john@example.com")
+}
+
+func TestExtractTextLines_NotAPDF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.pdf")
+	require.NoError(t, os.WriteFile(path, []byte("plain text"), 0644))
+
+	_, err := ExtractTextLines(path)
+	assert.Error(t, err)
+}
+
+func TestCompareReports(t *testing.T) {
+	className := "Grade 10"
+	base := &models.Student{ID: 1, Name: "John Doe", Email: "john@example.com", Class: &className}
+
+	t.Run("Identical reports", func(t *testing.T) {
+		diff, err := CompareReports(generateTestReport(t, base), generateTestReport(t, base))
+		require.NoError(t, err)
+
+		assert.True(t, diff.Identical)
+		assert.Empty(t, diff.Added)
+		assert.Empty(t, diff.Removed)
+		assert.Empty(t, diff.Changed)
+	})
+
+	t.Run("Changed field", func(t *testing.T) {
+		changed := *base
+		changed.Email = "john.doe@example.com"
+
+		diff, err := CompareReports(generateTestReport(t, base), generateTestReport(t, &changed))
+		require.NoError(t, err)
+
+		assert.False(t, diff.Identical)
+		assert.Contains(t, diff.Changed, FieldChange{Field: "Email Address", OldValue: "john@example.com", NewValue: "john.doe@example.com"})
+		assert.Contains(t, diff.Changed, FieldChange{Field: "Primary Email", OldValue: "john@example.com", NewValue: "john.doe@example.com"})
+		assert.Empty(t, diff.Added)
+		assert.Empty(t, diff.Removed)
+	})
+
+	t.Run("Added lines", func(t *testing.T) {
+		gender := "Male"
+		changed := *base
+		changed.Gender = &gender
+
+		diff, err := CompareReports(generateTestReport(t, base), generateTestReport(t, &changed))
+		require.NoError(t, err)
+
+		assert.False(t, diff.Identical)
+		assert.Equal(t, []string{"Gender: Male"}, diff.Added)
+		assert.Empty(t, diff.Removed)
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := CompareReports(filepath.Join(t.TempDir(), "missing.pdf"), generateTestReport(t, base))
+		assert.Error(t, err)
+	})
+}
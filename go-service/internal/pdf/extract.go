@@ -0,0 +1,397 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// The extractor below understands the PDFs this package writes: one content
+// stream per page, optionally Flate-compressed, with text drawn through the
+// standard Tj/TJ operators. It is not a general-purpose PDF parser.
+
+var (
+	pageContentsPattern = regexp.MustCompile(`/Type /Page\b[^>]*?/Contents (\d+) 0 R`)
+	streamLengthPattern = regexp.MustCompile(`/Length (\d+)`)
+)
+
+// ExtractTextLines returns the text layer of a PDF as lines in reading order.
+// PDFs without a text layer yield no lines and no error.
+func ExtractTextLines(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PDF: %w", err)
+	}
+
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		return nil, fmt.Errorf("%s is not a PDF file", path)
+	}
+
+	var lines []string
+	for _, match := range pageContentsPattern.FindAllSubmatch(data, -1) {
+		objectNumber, _ := strconv.Atoi(string(match[1]))
+
+		content, err := readStream(data, objectNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read page content: %w", err)
+		}
+
+		lines = append(lines, contentTextLines(content)...)
+	}
+
+	return lines, nil
+}
+
+// ExtractText returns the text layer of a PDF as newline separated lines
+func ExtractText(path string) (string, error) {
+	lines, err := ExtractTextLines(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// readStream returns the decoded data of the stream object with the given number
+func readStream(data []byte, objectNumber int) ([]byte, error) {
+	header := []byte(fmt.Sprintf("\n%d 0 obj", objectNumber))
+	start := bytes.Index(data, header)
+	if start < 0 {
+		return nil, fmt.Errorf("object %d not found", objectNumber)
+	}
+	data = data[start+len(header):]
+
+	streamStart := bytes.Index(data, []byte("stream"))
+	if streamStart < 0 {
+		return nil, fmt.Errorf("object %d has no stream", objectNumber)
+	}
+	dict := data[:streamStart]
+
+	lengthMatch := streamLengthPattern.FindSubmatch(dict)
+	if lengthMatch == nil {
+		return nil, fmt.Errorf("object %d has no direct stream length", objectNumber)
+	}
+	length, _ := strconv.Atoi(string(lengthMatch[1]))
+
+	body := data[streamStart+len("stream"):]
+	body = bytes.TrimPrefix(body, []byte("\r"))
+	body = bytes.TrimPrefix(body, []byte("\n"))
+	if length > len(body) {
+		return nil, fmt.Errorf("object %d stream is truncated", objectNumber)
+	}
+	body = body[:length]
+
+	if !bytes.Contains(dict, []byte("/FlateDecode")) {
+		return body, nil
+	}
+
+	reader, err := zlib.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("object %d: %w", objectNumber, err)
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// contentTextLines interprets the text operators of a page content stream.
+// Text drawn at the same baseline is joined into one line.
+func contentTextLines(content []byte) []string {
+	var (
+		lines    []string
+		current  []string
+		operands []interface{}
+		lastY    float64
+		y        float64
+		hasLine  bool
+	)
+
+	flush := func() {
+		if len(current) > 0 {
+			lines = append(lines, strings.Join(current, " "))
+			current = nil
+		}
+	}
+
+	emit := func(text string) {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return
+		}
+		if hasLine && y != lastY {
+			flush()
+		}
+		current = append(current, text)
+		lastY = y
+		hasLine = true
+	}
+
+	lexer := &contentLexer{data: content}
+	for {
+		token, ok := lexer.next()
+		if !ok {
+			break
+		}
+
+		op, isOperator := token.(contentOperator)
+		if !isOperator {
+			operands = append(operands, token)
+			continue
+		}
+
+		switch op {
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				if dy, ok := operands[len(operands)-1].(float64); ok {
+					y = dy
+				}
+			}
+		case "Tm":
+			if len(operands) >= 6 {
+				if f, ok := operands[len(operands)-1].(float64); ok {
+					y = f
+				}
+			}
+		case "T*":
+			flush()
+		case "Tj", "'", "\"":
+			if len(operands) > 0 {
+				if text, ok := operands[len(operands)-1].(string); ok {
+					emit(text)
+				}
+			}
+		case "TJ":
+			if len(operands) > 0 {
+				if parts, ok := operands[len(operands)-1].([]interface{}); ok {
+					var sb strings.Builder
+					for _, part := range parts {
+						switch v := part.(type) {
+						case string:
+							sb.WriteString(v)
+						case float64:
+							// Large negative kerning is how PDFs encode word gaps
+							if v < -200 {
+								sb.WriteByte(' ')
+							}
+						}
+					}
+					emit(sb.String())
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+	flush()
+
+	return lines
+}
+
+// contentOperator is a content stream operator such as Tj
+type contentOperator string
+
+// contentLexer tokenizes a content stream into numbers (float64), strings,
+// arrays ([]interface{}), names (ignored) and operators
+type contentLexer struct {
+	data []byte
+	pos  int
+}
+
+func (l *contentLexer) next() (interface{}, bool) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, false
+	}
+
+	c := l.data[l.pos]
+	switch {
+	case c == '(':
+		return l.literalString(), true
+	case c == '<' && l.pos+1 < len(l.data) && l.data[l.pos+1] != '<':
+		return l.hexString(), true
+	case c == '[':
+		l.pos++
+		var items []interface{}
+		for {
+			l.skipSpace()
+			if l.pos >= len(l.data) {
+				return items, true
+			}
+			if l.data[l.pos] == ']' {
+				l.pos++
+				return items, true
+			}
+			item, ok := l.next()
+			if !ok {
+				return items, true
+			}
+			items = append(items, item)
+		}
+	case c == '/':
+		l.pos++
+		l.word()
+		return nil, true
+	case c == '-' || c == '+' || c == '.' || (c >= '0' && c <= '9'):
+		value, err := strconv.ParseFloat(l.word(), 64)
+		if err != nil {
+			return nil, true
+		}
+		return value, true
+	default:
+		word := l.word()
+		if word == "" {
+			// Skip delimiters we don't interpret, e.g. dictionaries
+			l.pos++
+			return nil, true
+		}
+		return contentOperator(word), true
+	}
+}
+
+func (l *contentLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		switch l.data[l.pos] {
+		case ' ', '\t', '\r', '\n', '\f', 0:
+			l.pos++
+		case '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (l *contentLexer) word() string {
+	start := l.pos
+	for l.pos < len(l.data) {
+		switch l.data[l.pos] {
+		case ' ', '\t', '\r', '\n', '\f', 0, '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+			return string(l.data[start:l.pos])
+		}
+		l.pos++
+	}
+	return string(l.data[start:l.pos])
+}
+
+func (l *contentLexer) literalString() string {
+	l.pos++ // opening parenthesis
+	var out []byte
+	depth := 1
+
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+
+		switch c {
+		case '(':
+			depth++
+			out = append(out, c)
+		case ')':
+			depth--
+			if depth == 0 {
+				return decodePDFDocText(out)
+			}
+			out = append(out, c)
+		case '\\':
+			if l.pos >= len(l.data) {
+				break
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b':
+				out = append(out, '\b')
+			case 'f':
+				out = append(out, '\f')
+			case '\r', '\n':
+				// Line continuation
+			default:
+				if e >= '0' && e <= '7' {
+					value := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						value = value*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					out = append(out, byte(value))
+				} else {
+					out = append(out, e)
+				}
+			}
+		default:
+			out = append(out, c)
+		}
+	}
+
+	return decodePDFDocText(out)
+}
+
+func (l *contentLexer) hexString() string {
+	l.pos++ // opening angle bracket
+	end := bytes.IndexByte(l.data[l.pos:], '>')
+	if end < 0 {
+		l.pos = len(l.data)
+		return ""
+	}
+
+	hex := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(" \t\r\n", r) {
+			return -1
+		}
+		return r
+	}, string(l.data[l.pos:l.pos+end]))
+	l.pos += end + 1
+
+	if len(hex)%2 == 1 {
+		hex += "0"
+	}
+
+	out := make([]byte, 0, len(hex)/2)
+	for i := 0; i < len(hex); i += 2 {
+		value, err := strconv.ParseUint(hex[i:i+2], 16, 8)
+		if err != nil {
+			return ""
+		}
+		out = append(out, byte(value))
+	}
+
+	return decodePDFDocText(out)
+}
+
+// cp1252 maps the bytes 0x80-0x9F of the core fonts' encoding that differ from Latin-1
+var cp1252 = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡',
+	0x88: 'ˆ', 0x89: '‰', 0x8A: 'Š', 0x8B: '‹', 0x8C: 'Œ', 0x8E: 'Ž',
+	0x91: '‘', 0x92: '’', 0x93: '“', 0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—',
+	0x98: '˜', 0x99: '™', 0x9A: 'š', 0x9B: '›', 0x9C: 'œ', 0x9E: 'ž', 0x9F: 'Ÿ',
+}
+
+// decodePDFDocText converts string operands to UTF-8. The generator passes Go
+// strings through unchanged, so valid UTF-8 is kept as is; anything else is
+// treated as the core fonts' single-byte encoding.
+func decodePDFDocText(b []byte) string {
+	if utf8.Valid(b) {
+		return string(b)
+	}
+
+	var sb strings.Builder
+	for _, c := range b {
+		if r, ok := cp1252[c]; ok {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune(rune(c))
+		}
+	}
+	return sb.String()
+}
@@ -1,7 +1,12 @@
 package pdf
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"mime"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,12 +16,22 @@ import (
 	"student-report-service/internal/models"
 
 	"github.com/jung-kurt/gofpdf"
+	"github.com/sirupsen/logrus"
 )
 
+// ErrFileTooLarge is returned when a generated report exceeds the maximum file size
+var ErrFileTooLarge = errors.New("exceeds maximum file size limit")
+
 // Generator handles PDF report generation
 type Generator struct {
 	config    *config.ReportConfig
 	outputDir string
+	logger    *logrus.Logger
+	remove    func(path string) error
+	fileInfo  func(entry fs.DirEntry) (fs.FileInfo, error)
+	now       func() time.Time
+	// index is nil when the report index is disabled
+	index *reportIndex
 }
 
 // NewGenerator creates a new PDF generator
@@ -30,10 +45,29 @@ func NewGenerator(cfg *config.ReportConfig) (*Generator, error) {
 		return nil, fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	return &Generator{
+	g := &Generator{
 		config:    cfg,
 		outputDir: cfg.OutputDir,
-	}, nil
+		remove:    os.Remove,
+		fileInfo:  fs.DirEntry.Info,
+		now:       time.Now,
+	}
+	if cfg.Index {
+		g.index = newReportIndex()
+	}
+	return g, nil
+}
+
+// SetLogger enables logging of report collisions and cleanup failures
+func (g *Generator) SetLogger(logger *logrus.Logger) {
+	g.logger = logger
+}
+
+// SetClock makes generated reports reproducible for fixtures and golden
+// tests: file names, default metadata and PDF creation dates all use now
+// instead of the current time
+func (g *Generator) SetClock(now func() time.Time) {
+	g.now = now
 }
 
 // GenerateStudentReport generates a comprehensive PDF report for a student
@@ -44,60 +78,135 @@ func (g *Generator) GenerateStudentReport(student *models.Student, metadata *mod
 
 	if metadata == nil {
 		metadata = &models.ReportMetadata{
-			GeneratedAt: time.Now(),
+			GeneratedAt: g.now(),
 			GeneratedBy: "System",
-			ReportID:    fmt.Sprintf("RPT-%d-%d", student.ID, time.Now().Unix()),
+			ReportID:    StudentReportID(student.ID, g.now(), config.ReportFormatPDF),
 		}
 	}
 
-	// Create PDF instance
+	filename := g.studentReportFilename(student, ".pdf")
+
+	return g.writeReport(filename, metadata, func() (*gofpdf.Fpdf, error) {
+		pdf := g.newDocument()
+		g.addStudentReport(pdf, student, metadata)
+		if err := g.checkPageLimit(pdf, metadata); err != nil {
+			return nil, err
+		}
+		return pdf, nil
+	})
+}
+
+// studentReportFilename names a student's report file with the given extension
+func (g *Generator) studentReportFilename(student *models.Student, ext string) string {
+	sanitizedName := g.sanitizeFilename(student.FormatName())
+	return fmt.Sprintf("student_report_%d_%s_%s%s",
+		student.ID,
+		sanitizedName,
+		g.now().Format("20060102_150405"),
+		ext)
+}
+
+// newDocument creates an empty PDF with the report page layout
+func (g *Generator) newDocument() *gofpdf.Fpdf {
 	pdf := gofpdf.New("P", "mm", "A4", "")
+	created := g.now()
+	pdf.SetCreationDate(created)
+	pdf.SetModificationDate(created)
+	// A fixed resource order keeps documents with the same content byte-identical
+	pdf.SetCatalogSort(true)
 	pdf.SetMargins(20, 20, 20)
 	pdf.SetAutoPageBreak(true, 20)
+	g.limitPages(pdf)
+	return pdf
+}
+
+// addStudentReport renders a complete student report starting on a new page,
+// preceded by its cover letter if it has one
+func (g *Generator) addStudentReport(pdf *gofpdf.Fpdf, student *models.Student, metadata *models.ReportMetadata) {
+	if metadata.CoverLetter != "" {
+		g.addCoverLetter(pdf, metadata.CoverLetter)
+	}
 
-	// Add page
-	pdf.AddPage()
+	g.addPage(pdf)
 
-	// Generate the report content
 	g.addHeader(pdf, metadata)
-	g.addStudentBasicInfo(pdf, student)
-	g.addContactDetails(pdf, student)
-	g.addFamilyInformation(pdf, student)
-	g.addAddressInformation(pdf, student)
-	g.addAcademicInformation(pdf, student)
+	if metadata.ShowPhoto {
+		g.addPhoto(pdf, student, metadata)
+	}
+	for _, section := range g.studentSections(student, metadata) {
+		g.addSection(pdf, section)
+	}
 	g.addFooter(pdf, metadata)
+}
 
-	// Generate filename
-	sanitizedName := g.sanitizeFilename(student.FormatName())
-	filename := fmt.Sprintf("student_report_%d_%s_%s.pdf",
-		student.ID,
-		sanitizedName,
-		time.Now().Format("20060102_150405"))
+// savePDF writes the document to the output directory, enforcing the size
+// limit. It never replaces an existing file: ErrReportExists is returned instead.
+func (g *Generator) savePDF(pdf *gofpdf.Fpdf, filename string) (string, error) {
+	return g.saveReportFile(filename, "PDF", pdf.Output)
+}
 
+// saveReportFile creates filename in the output directory and fills it with
+// write. kind names the format in errors.
+func (g *Generator) saveReportFile(filename, kind string, write func(io.Writer) error) (string, error) {
 	filepath := filepath.Join(g.outputDir, filename)
 
-	// Save the PDF
-	if err := pdf.OutputFileAndClose(filepath); err != nil {
-		return "", fmt.Errorf("failed to save PDF: %w", err)
+	// Save the report
+	file, err := os.OpenFile(filepath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if errors.Is(err, fs.ErrExist) {
+		return "", fmt.Errorf("%w: %s", ErrReportExists, filename)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to save %s: %w", kind, err)
+	}
+
+	err = write(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(filepath)
+		return "", fmt.Errorf("failed to save %s: %w", kind, err)
 	}
 
 	// Check file size
 	if fileInfo, err := os.Stat(filepath); err == nil {
 		if fileInfo.Size() > g.config.MaxFileSize {
 			os.Remove(filepath) // Clean up oversized file
-			return "", fmt.Errorf("generated PDF exceeds maximum file size limit")
+			return "", fmt.Errorf("generated %s %w", kind, ErrFileTooLarge)
 		}
 	}
 
 	return filepath, nil
 }
 
+// addCoverLetter adds the cover letter on a page of its own. Lines are always
+// wrapped, whatever the text overflow setting, so no part of the letter is lost.
+func (g *Generator) addCoverLetter(pdf *gofpdf.Fpdf, letter string) {
+	g.addPage(pdf)
+	pdf.SetFont("Arial", "", 11)
+	pdf.SetTextColor(0, 0, 0)
+
+	pageWidth, _ := pdf.GetPageSize()
+	leftMargin, _, rightMargin, _ := pdf.GetMargins()
+	width := pageWidth - leftMargin - rightMargin
+
+	for _, paragraph := range strings.Split(strings.ReplaceAll(letter, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(paragraph) == "" {
+			pdf.Ln(6)
+			continue
+		}
+		for _, line := range wrapText(pdf, paragraph, width) {
+			pdf.CellFormat(width, 6, line, "", 1, "L", false, 0, "")
+		}
+	}
+}
+
 // addHeader adds the report header with title and metadata
 func (g *Generator) addHeader(pdf *gofpdf.Fpdf, metadata *models.ReportMetadata) {
 	// Title
 	pdf.SetFont("Arial", "B", 20)
 	pdf.SetTextColor(0, 51, 102) // Dark blue
-	pdf.CellFormat(0, 15, "Student Information Report", "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 15, reportTitle, "", 1, "C", false, 0, "")
 	pdf.Ln(5)
 
 	// Metadata section
@@ -105,110 +214,86 @@ func (g *Generator) addHeader(pdf *gofpdf.Fpdf, metadata *models.ReportMetadata)
 	pdf.SetTextColor(100, 100, 100) // Gray
 
 	// Report details
-	pdf.CellFormat(0, 5, fmt.Sprintf("Report ID: %s", metadata.ReportID), "", 1, "R", false, 0, "")
-	pdf.CellFormat(0, 5, fmt.Sprintf("Generated: %s", metadata.GeneratedAt.Format("January 2, 2006 at 15:04 MST")), "", 1, "R", false, 0, "")
-	pdf.CellFormat(0, 5, fmt.Sprintf("Generated by: %s", metadata.GeneratedBy), "", 1, "R", false, 0, "")
+	for _, line := range reportDetailLines(metadata) {
+		g.addRightAlignedLine(pdf, line)
+	}
 
 	pdf.Ln(10)
 
 	// Add watermark
-	if g.config.WatermarkText != "" {
-		g.addWatermark(pdf, g.config.WatermarkText)
-	}
+	g.addReportWatermark(pdf, metadata)
 }
 
-// addStudentBasicInfo adds basic student information section
-func (g *Generator) addStudentBasicInfo(pdf *gofpdf.Fpdf, student *models.Student) {
-	g.addSectionHeader(pdf, "Basic Information")
-
-	// Basic info table
-	g.addInfoRow(pdf, "Student ID:", fmt.Sprintf("%d", student.ID))
-	g.addInfoRow(pdf, "Full Name:", student.FormatName())
-	g.addInfoRow(pdf, "Email Address:", student.FormatEmail())
-	g.addInfoRow(pdf, "System Access:", g.formatBool(student.SystemAccess))
-
-	if student.Gender != nil {
-		g.addInfoRow(pdf, "Gender:", models.SafeString(student.Gender, "Not specified"))
+// addReportWatermark adds the report's watermark, falling back to the configured text
+func (g *Generator) addReportWatermark(pdf *gofpdf.Fpdf, metadata *models.ReportMetadata) {
+	if watermark := g.reportWatermark(metadata); watermark != "" {
+		g.addWatermark(pdf, watermark)
 	}
-
-	if student.DOB != nil {
-		g.addInfoRow(pdf, "Date of Birth:", models.SafeString(student.DOB, "Not specified"))
-	}
-
-	if student.Phone != nil {
-		g.addInfoRow(pdf, "Phone Number:", models.SafeString(student.Phone, "Not provided"))
-	}
-
-	pdf.Ln(5)
 }
 
-// addContactDetails adds contact information section
-func (g *Generator) addContactDetails(pdf *gofpdf.Fpdf, student *models.Student) {
-	g.addSectionHeader(pdf, "Contact Information")
-
-	g.addInfoRow(pdf, "Primary Email:", student.FormatEmail())
-	g.addInfoRow(pdf, "Phone Number:", models.SafeString(student.Phone, "Not provided"))
-
-	pdf.Ln(5)
-}
-
-// addFamilyInformation adds family and guardian information
-func (g *Generator) addFamilyInformation(pdf *gofpdf.Fpdf, student *models.Student) {
-	g.addSectionHeader(pdf, "Family & Guardian Information")
-
-	// Father's information
-	g.addSubsectionHeader(pdf, "Father's Information")
-	g.addInfoRow(pdf, "Father's Name:", models.SafeString(student.FatherName, "Not provided"))
-	g.addInfoRow(pdf, "Father's Phone:", models.SafeString(student.FatherPhone, "Not provided"))
-
-	pdf.Ln(3)
-
-	// Mother's information
-	g.addSubsectionHeader(pdf, "Mother's Information")
-	g.addInfoRow(pdf, "Mother's Name:", models.SafeString(student.MotherName, "Not provided"))
-	g.addInfoRow(pdf, "Mother's Phone:", models.SafeString(student.MotherPhone, "Not provided"))
-
-	pdf.Ln(3)
-
-	// Guardian information
-	g.addSubsectionHeader(pdf, "Guardian Information")
-	g.addInfoRow(pdf, "Guardian's Name:", models.SafeString(student.GuardianName, "Not provided"))
-	g.addInfoRow(pdf, "Guardian's Phone:", models.SafeString(student.GuardianPhone, "Not provided"))
-	g.addInfoRow(pdf, "Relation to Student:", models.SafeString(student.RelationOfGuardian, "Not specified"))
-
-	pdf.Ln(5)
-}
-
-// addAddressInformation adds address information
-func (g *Generator) addAddressInformation(pdf *gofpdf.Fpdf, student *models.Student) {
-	g.addSectionHeader(pdf, "Address Information")
+// Photo area placement, to the left of the right-aligned report metadata
+const (
+	photoX      = 20.0
+	photoY      = 37.0
+	photoWidth  = 25.0
+	photoHeight = 30.0
+)
 
-	g.addInfoRow(pdf, "Current Address:", models.SafeString(student.CurrentAddress, "Not provided"))
-	g.addInfoRow(pdf, "Permanent Address:", models.SafeString(student.PermanentAddress, "Not provided"))
+// addPhoto draws the student photo, or a placeholder when there is no usable photo
+func (g *Generator) addPhoto(pdf *gofpdf.Fpdf, student *models.Student, metadata *models.ReportMetadata) {
+	if !g.drawPhoto(pdf, student, metadata) {
+		pdf.SetDrawColor(180, 180, 180)
+		pdf.Rect(photoX, photoY, photoWidth, photoHeight, "D")
+		pdf.SetFont("Arial", "I", 8)
+		pdf.SetTextColor(150, 150, 150)
+		pdf.SetXY(photoX, photoY+photoHeight/2-2)
+		pdf.CellFormat(photoWidth, 4, "No Photo", "", 0, "C", false, 0, "")
+	}
 
-	pdf.Ln(5)
+	// Keep the first section clear of the photo
+	if bottom := photoY + photoHeight + 5; pdf.GetY() < bottom {
+		pdf.SetY(bottom)
+	}
 }
 
-// addAcademicInformation adds academic information
-func (g *Generator) addAcademicInformation(pdf *gofpdf.Fpdf, student *models.Student) {
-	g.addSectionHeader(pdf, "Academic Information")
+// drawPhoto embeds the photo image and reports whether it succeeded
+func (g *Generator) drawPhoto(pdf *gofpdf.Fpdf, student *models.Student, metadata *models.ReportMetadata) bool {
+	photo := metadata.Photo
+	if photo == nil || len(photo.Data) == 0 {
+		return false
+	}
 
-	g.addInfoRow(pdf, "Class:", models.SafeString(student.Class, "Not assigned"))
-	g.addInfoRow(pdf, "Section:", models.SafeString(student.Section, "Not assigned"))
+	imageType := photoImageType(photo.ContentType)
+	if imageType == "" {
+		return false
+	}
 
-	if student.Roll != nil {
-		g.addInfoRow(pdf, "Roll Number:", fmt.Sprintf("%d", models.SafeInt(student.Roll, 0)))
-	} else {
-		g.addInfoRow(pdf, "Roll Number:", "Not assigned")
+	name := fmt.Sprintf("photo-%d", student.ID)
+	options := gofpdf.ImageOptions{ImageType: imageType}
+	pdf.RegisterImageOptionsReader(name, options, bytes.NewReader(photo.Data))
+	if !pdf.Ok() {
+		// A corrupt photo shouldn't fail the whole report
+		pdf.ClearError()
+		return false
 	}
 
-	g.addInfoRow(pdf, "Admission Date:", models.SafeString(student.AdmissionDate, "Not recorded"))
+	pdf.ImageOptions(name, photoX, photoY, photoWidth, photoHeight, false, options, 0, "")
+	return true
+}
 
-	if student.ReporterName != nil {
-		g.addInfoRow(pdf, "Reporter:", models.SafeString(student.ReporterName, "System"))
+// photoImageType maps a photo content type to a gofpdf image type
+func photoImageType(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "image/jpeg", "image/jpg":
+		return "JPG"
+	case "image/png":
+		return "PNG"
+	case "image/gif":
+		return "GIF"
+	default:
+		return ""
 	}
-
-	pdf.Ln(5)
 }
 
 // addFooter adds the report footer
@@ -217,9 +302,28 @@ func (g *Generator) addFooter(pdf *gofpdf.Fpdf, metadata *models.ReportMetadata)
 	pdf.SetFont("Arial", "I", 8)
 	pdf.SetTextColor(150, 150, 150)
 
-	pdf.CellFormat(0, 5, "This report is confidential and intended for authorized personnel only.", "", 1, "C", false, 0, "")
-	pdf.CellFormat(0, 5, fmt.Sprintf("Generated on %s", metadata.GeneratedAt.Format("January 2, 2006")), "", 1, "C", false, 0, "")
-	pdf.CellFormat(0, 5, "Student Management System", "", 1, "C", false, 0, "")
+	for _, line := range reportFooterLines(metadata) {
+		pdf.CellFormat(0, 5, line, "", 1, "C", false, 0, "")
+	}
+}
+
+// addSection adds a report section with its subsections
+func (g *Generator) addSection(pdf *gofpdf.Fpdf, section reportSection) {
+	g.addSectionHeader(pdf, section.Title)
+
+	for i, group := range section.Groups {
+		if i > 0 {
+			pdf.Ln(3)
+		}
+		if group.Title != "" {
+			g.addSubsectionHeader(pdf, group.Title)
+		}
+		for _, row := range group.Rows {
+			g.addInfoRow(pdf, row.Label+":", row.Value)
+		}
+	}
+
+	pdf.Ln(5)
 }
 
 // Helper methods for consistent formatting
@@ -244,7 +348,28 @@ func (g *Generator) addInfoRow(pdf *gofpdf.Fpdf, label, value string) {
 
 	pdf.SetFont("Arial", "", 10)
 	pdf.SetTextColor(51, 51, 51)
-	pdf.CellFormat(0, 6, value, "", 1, "L", false, 0, "")
+
+	valueX := pdf.GetX()
+	pageWidth, _ := pdf.GetPageSize()
+	_, _, rightMargin, _ := pdf.GetMargins()
+	width := pageWidth - rightMargin - valueX
+
+	for i, line := range g.fitText(pdf, value, width) {
+		if i > 0 {
+			pdf.SetX(valueX)
+		}
+		pdf.CellFormat(width, 6, line, "", 1, "L", false, 0, "")
+	}
+}
+
+// addRightAlignedLine adds a right-aligned metadata line, keeping long values within the margins
+func (g *Generator) addRightAlignedLine(pdf *gofpdf.Fpdf, text string) {
+	pageWidth, _ := pdf.GetPageSize()
+	leftMargin, _, rightMargin, _ := pdf.GetMargins()
+
+	for _, line := range g.fitText(pdf, text, pageWidth-leftMargin-rightMargin) {
+		pdf.CellFormat(0, 5, line, "", 1, "R", false, 0, "")
+	}
 }
 
 func (g *Generator) addWatermark(pdf *gofpdf.Fpdf, text string) {
@@ -280,23 +405,3 @@ func (g *Generator) sanitizeFilename(name string) string {
 
 	return result
 }
-
-// CleanupOldReports removes old report files based on configuration
-func (g *Generator) CleanupOldReports() error {
-	if !g.config.Cleanup {
-		return nil
-	}
-
-	return filepath.Walk(g.outputDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		if !info.IsDir() && strings.HasSuffix(info.Name(), ".pdf") {
-			if time.Since(info.ModTime()) > g.config.CleanupAfter {
-				return os.Remove(path)
-			}
-		}
-		return nil
-	})
-}
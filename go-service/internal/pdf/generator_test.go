@@ -0,0 +1,148 @@
+package pdf
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGenerator(t *testing.T) *Generator {
+	t.Helper()
+
+	generator, err := NewGenerator(&config.ReportConfig{
+		OutputDir:   t.TempDir(),
+		MaxFileSize: 10 * 1024 * 1024,
+	})
+	require.NoError(t, err)
+
+	return generator
+}
+
+func testMetadata() *models.ReportMetadata {
+	return &models.ReportMetadata{
+		GeneratedAt: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+		GeneratedBy: "Test",
+		ReportID:    "RPT-1",
+	}
+}
+
+func TestGenerator_Photo(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 10, 12))
+	img.Set(1, 1, color.RGBA{R: 255, A: 255})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	tests := []struct {
+		name                string
+		setup               func(*models.ReportMetadata)
+		expectedPlaceholder bool
+	}{
+		{
+			name:                "Photo disabled",
+			setup:               func(m *models.ReportMetadata) {},
+			expectedPlaceholder: false,
+		},
+		{
+			name: "Photo embedded",
+			setup: func(m *models.ReportMetadata) {
+				m.ShowPhoto = true
+				m.Photo = &models.StudentPhoto{Data: buf.Bytes(), ContentType: "image/png"}
+			},
+			expectedPlaceholder: false,
+		},
+		{
+			name: "Missing photo uses placeholder",
+			setup: func(m *models.ReportMetadata) {
+				m.ShowPhoto = true
+			},
+			expectedPlaceholder: true,
+		},
+		{
+			name: "Corrupt photo uses placeholder",
+			setup: func(m *models.ReportMetadata) {
+				m.ShowPhoto = true
+				m.Photo = &models.StudentPhoto{Data: []byte("not an image"), ContentType: "image/png"}
+			},
+			expectedPlaceholder: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := testMetadata()
+			tt.setup(metadata)
+
+			path, err := newTestGenerator(t).GenerateStudentReport(&models.Student{ID: 1, Name: "John Doe"}, metadata)
+			require.NoError(t, err)
+
+			lines, err := ExtractTextLines(path)
+			require.NoError(t, err)
+
+			if tt.expectedPlaceholder {
+				assert.Contains(t, lines, "No Photo")
+			} else {
+				assert.NotContains(t, lines, "No Photo")
+			}
+		})
+	}
+}
+
+func TestGenerator_AdditionalInformation(t *testing.T) {
+	metadata := testMetadata()
+	metadata.AddExtraField("Bus Route", "42")
+	metadata.AddExtraField("Locker", "B-12")
+
+	path, err := newTestGenerator(t).GenerateStudentReport(&models.Student{ID: 1, Name: "John Doe"}, metadata)
+	require.NoError(t, err)
+
+	lines, err := ExtractTextLines(path)
+	require.NoError(t, err)
+	assert.Contains(t, lines, "Additional Information")
+	assert.Contains(t, lines, "Bus Route: 42")
+	assert.Contains(t, lines, "Locker: B-12")
+}
+
+func TestGenerator_OnBehalfOf(t *testing.T) {
+	student := &models.Student{ID: 1, Name: "John Doe"}
+
+	path, err := newTestGenerator(t).GenerateStudentReport(student, testMetadata())
+	require.NoError(t, err)
+	lines, err := ExtractTextLines(path)
+	require.NoError(t, err)
+	for _, line := range lines {
+		assert.NotContains(t, line, "On behalf of")
+	}
+
+	metadata := testMetadata()
+	metadata.OnBehalfOf = "Ms. Smith"
+	path, err = newTestGenerator(t).GenerateStudentReport(student, metadata)
+	require.NoError(t, err)
+	lines, err = ExtractTextLines(path)
+	require.NoError(t, err)
+	assert.Contains(t, lines, "On behalf of: Ms. Smith")
+}
+
+func TestGenerator_CoverLetter(t *testing.T) {
+	metadata := testMetadata()
+	metadata.CoverLetter = "Dear Parent,\n\nPlease find enclosed the transcript requested for the scholarship application."
+
+	path, err := newTestGenerator(t).GenerateStudentReport(&models.Student{ID: 1, Name: "John Doe"}, metadata)
+	require.NoError(t, err)
+
+	lines, err := ExtractTextLines(path)
+	require.NoError(t, err)
+	require.NotEmpty(t, lines)
+
+	assert.Equal(t, "Dear Parent,", lines[0])
+	assert.Contains(t, lines, "Please find enclosed the transcript requested for the scholarship application.")
+	assert.Contains(t, lines, "Student Information Report")
+}
@@ -0,0 +1,96 @@
+package pdf
+
+import (
+	"fmt"
+	"strings"
+
+	"student-report-service/internal/models"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// GenerateHouseholdReport renders one PDF covering several siblings: a cover
+// page listing the household, followed by each member's full report. Pages
+// are numbered continuously across the whole document.
+func (g *Generator) GenerateHouseholdReport(members []models.HouseholdMember, failures []models.HouseholdFailure, metadata *models.ReportMetadata) (string, error) {
+	if len(members) == 0 {
+		return "", fmt.Errorf("household report needs at least one student")
+	}
+
+	if metadata == nil {
+		metadata = &models.ReportMetadata{
+			GeneratedAt: g.now(),
+			GeneratedBy: "System",
+			ReportID:    fmt.Sprintf("RPT-HH-%d-%d", members[0].Student.ID, g.now().Unix()),
+		}
+	}
+
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, fmt.Sprintf("%d", member.Student.ID))
+	}
+	filename := fmt.Sprintf("household_report_%s_%s.pdf",
+		g.sanitizeFilename(strings.Join(ids, "_")),
+		g.now().Format("20060102_150405"))
+
+	return g.writeReport(filename, metadata, func() (*gofpdf.Fpdf, error) {
+		pdf := g.newDocument()
+		pdf.AliasNbPages("")
+		pdf.SetFooterFunc(func() {
+			pdf.SetY(-12)
+			pdf.SetFont("Arial", "", 8)
+			pdf.SetTextColor(150, 150, 150)
+			pdf.CellFormat(0, 5, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+		})
+
+		g.addHouseholdCover(pdf, members, failures, metadata)
+
+		for _, member := range members {
+			// Member sections carry the household report ID, which may have been regenerated
+			member.Metadata.ReportID = metadata.ReportID
+			g.addStudentReport(pdf, member.Student, member.Metadata)
+		}
+		if err := g.checkPageLimit(pdf, metadata); err != nil {
+			return nil, err
+		}
+		return pdf, nil
+	})
+}
+
+// addHouseholdCover adds the cover page listing every household member
+func (g *Generator) addHouseholdCover(pdf *gofpdf.Fpdf, members []models.HouseholdMember, failures []models.HouseholdFailure, metadata *models.ReportMetadata) {
+	g.addPage(pdf)
+
+	pdf.SetFont("Arial", "B", 20)
+	pdf.SetTextColor(0, 51, 102)
+	pdf.CellFormat(0, 15, "Household Report", "", 1, "C", false, 0, "")
+	pdf.Ln(5)
+
+	pdf.SetFont("Arial", "", 10)
+	pdf.SetTextColor(100, 100, 100)
+	for _, line := range reportDetailLines(metadata) {
+		g.addRightAlignedLine(pdf, line)
+	}
+	pdf.Ln(10)
+
+	g.addReportWatermark(pdf, metadata)
+
+	g.addSectionHeader(pdf, "Students in this Household")
+	for _, member := range members {
+		student := member.Student
+		class := models.SafeString(student.Class, "Not assigned")
+		if section := models.SafeString(student.Section, ""); section != "" {
+			class += " - " + section
+		}
+		g.addInfoRow(pdf, student.FormatName(), fmt.Sprintf("ID %d, %s", student.ID, class))
+	}
+	pdf.Ln(5)
+
+	if len(failures) > 0 {
+		g.addSectionHeader(pdf, "Reports Not Included")
+		for _, failure := range failures {
+			g.addInfoRow(pdf, fmt.Sprintf("Student ID %d:", failure.StudentID), failure.Reason)
+		}
+		pdf.Ln(5)
+	}
+}
@@ -0,0 +1,52 @@
+package pdf
+
+import (
+	"fmt"
+	"testing"
+
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_GenerateHouseholdReport(t *testing.T) {
+	members := []models.HouseholdMember{
+		{Student: &models.Student{ID: 1, Name: "John Doe"}, Metadata: testMetadata()},
+		{Student: &models.Student{ID: 2, Name: "Jane Doe"}, Metadata: testMetadata()},
+	}
+	failures := []models.HouseholdFailure{{StudentID: 3, Reason: "student with ID 3 not found"}}
+
+	path, err := newTestGenerator(t).GenerateHouseholdReport(members, failures, testMetadata())
+	require.NoError(t, err)
+
+	lines, err := ExtractTextLines(path)
+	require.NoError(t, err)
+
+	// Cover page lists the household and the missing sibling
+	assert.Equal(t, "Household Report", lines[0])
+	assert.Contains(t, lines, "John Doe ID 1, Not assigned")
+	assert.Contains(t, lines, "Jane Doe ID 2, Not assigned")
+	assert.Contains(t, lines, "Student ID 3: student with ID 3 not found")
+
+	// Each sibling gets a full report section
+	assert.Contains(t, lines, "Full Name: John Doe")
+	assert.Contains(t, lines, "Full Name: Jane Doe")
+
+	// Page numbers run continuously across sections
+	var pageLines []string
+	for _, line := range lines {
+		if len(line) > 5 && line[:5] == "Page " {
+			pageLines = append(pageLines, line)
+		}
+	}
+	require.NotEmpty(t, pageLines)
+	for i, line := range pageLines {
+		assert.Equal(t, fmt.Sprintf("Page %d of %d", i+1, len(pageLines)), line)
+	}
+}
+
+func TestGenerator_GenerateHouseholdReport_NoMembers(t *testing.T) {
+	_, err := newTestGenerator(t).GenerateHouseholdReport(nil, nil, testMetadata())
+	assert.Error(t, err)
+}
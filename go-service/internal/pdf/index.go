@@ -0,0 +1,192 @@
+package pdf
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrIndexDisabled is returned when rebuilding the report index while it is turned off
+var ErrIndexDisabled = errors.New("report index is disabled")
+
+// reportIDLinePrefix starts the line of a report's text that holds its report ID
+const reportIDLinePrefix = "Report ID: "
+
+// reportIndex maps report IDs to the files they are saved in, so that
+// lookups don't have to read every report. Reports are never overwritten,
+// so an entry stays correct for as long as its file exists.
+type reportIndex struct {
+	mu    sync.RWMutex
+	paths map[string]string
+
+	// Changes made while a rebuild reads the output directory, applied on
+	// top of its result so that concurrent reports are not lost
+	rebuilding int
+	added      map[string]string
+	removed    map[string]bool
+}
+
+func newReportIndex() *reportIndex {
+	return &reportIndex{paths: make(map[string]string)}
+}
+
+// lookup returns the indexed path of a report
+func (x *reportIndex) lookup(reportID string) (string, bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	path, ok := x.paths[reportID]
+	return path, ok
+}
+
+// add records that a report was saved at path
+func (x *reportIndex) add(reportID, path string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.paths[reportID] = path
+	if x.rebuilding > 0 {
+		x.added[reportID] = path
+		delete(x.removed, path)
+	}
+}
+
+// removePath drops the entry of a deleted report file
+func (x *reportIndex) removePath(path string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.deletePath(x.paths, path)
+	if x.rebuilding > 0 {
+		x.deletePath(x.added, path)
+		x.removed[path] = true
+	}
+}
+
+// deletePath removes the entries of paths pointing at path. Callers hold mu.
+func (x *reportIndex) deletePath(paths map[string]string, path string) {
+	for reportID, indexed := range paths {
+		if indexed == path {
+			delete(paths, reportID)
+		}
+	}
+}
+
+// startRebuild begins tracking the changes made during a rebuild
+func (x *reportIndex) startRebuild() {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.rebuilding == 0 {
+		x.added = make(map[string]string)
+		x.removed = make(map[string]bool)
+	}
+	x.rebuilding++
+}
+
+// finishRebuild replaces the entries with paths, read from the output
+// directory, and the changes made since the rebuild started. A failed
+// rebuild keeps the current entries.
+func (x *reportIndex) finishRebuild(paths map[string]string, err error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if err == nil {
+		for reportID, path := range x.added {
+			paths[reportID] = path
+		}
+		for path := range x.removed {
+			x.deletePath(paths, path)
+		}
+		x.paths = paths
+	}
+	x.rebuilding--
+	if x.rebuilding == 0 {
+		x.added, x.removed = nil, nil
+	}
+}
+
+// size returns the number of indexed reports
+func (x *reportIndex) size() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.paths)
+}
+
+// RebuildIndex replaces the report index with the reports found in the
+// output directory and returns how many were indexed. Reports generated or
+// deleted while it runs are kept consistent. Files whose report ID can't be
+// read are left out and found by scanning on lookup.
+func (g *Generator) RebuildIndex() (int, error) {
+	if g.index == nil {
+		return 0, ErrIndexDisabled
+	}
+
+	g.index.startRebuild()
+	paths, err := g.scanReportIDs()
+	g.index.finishRebuild(paths, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to rebuild report index: %w", err)
+	}
+	return g.index.size(), nil
+}
+
+// scanReportIDs reads the report ID of every report in the output directory
+func (g *Generator) scanReportIDs() (map[string]string, error) {
+	paths := make(map[string]string)
+	err := filepath.WalkDir(g.outputDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !isReportFile(entry.Name()) {
+			return nil
+		}
+
+		lines, err := reportLines(path)
+		if err != nil {
+			if g.logger != nil {
+				g.logger.WithError(err).WithField("path", path).Warn("Failed to index report")
+			}
+			return nil
+		}
+		for _, line := range lines {
+			if reportID, ok := strings.CutPrefix(line, reportIDLinePrefix); ok {
+				paths[reportID] = path
+				break
+			}
+		}
+		return nil
+	})
+	return paths, err
+}
+
+// indexedReport returns the indexed path of a report if its file still
+// exists. Stale entries are dropped.
+func (g *Generator) indexedReport(reportID string) (string, bool) {
+	if g.index == nil {
+		return "", false
+	}
+
+	path, ok := g.index.lookup(reportID)
+	if !ok {
+		return "", false
+	}
+	if _, err := os.Stat(path); err != nil {
+		g.index.removePath(path)
+		return "", false
+	}
+	return path, true
+}
+
+// indexReport records a saved report in the index, if it is enabled
+func (g *Generator) indexReport(reportID, path string) {
+	if g.index != nil {
+		g.index.add(reportID, path)
+	}
+}
+
+// unindexReport drops a deleted report from the index, if it is enabled
+func (g *Generator) unindexReport(path string) {
+	if g.index != nil {
+		g.index.removePath(path)
+	}
+}
@@ -0,0 +1,196 @@
+package pdf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newIndexedGenerator returns a generator with the report index enabled, writing to dir
+func newIndexedGenerator(t *testing.T, dir string) *Generator {
+	t.Helper()
+
+	generator, err := NewGenerator(&config.ReportConfig{
+		OutputDir:   dir,
+		MaxFileSize: 10 * 1024 * 1024,
+		Index:       true,
+	})
+	require.NoError(t, err)
+
+	return generator
+}
+
+// generateTextReport saves a text report for student id with reportID and returns its path
+func generateTextReport(t *testing.T, generator *Generator, id int, reportID string) string {
+	t.Helper()
+
+	metadata := testMetadata()
+	metadata.ReportID = reportID
+	path, err := generator.GenerateStudentTextReport(&models.Student{ID: id, Name: "John Doe"}, metadata)
+	require.NoError(t, err)
+
+	return path
+}
+
+func TestGenerator_ReportIndex(t *testing.T) {
+	t.Run("Generated reports are indexed", func(t *testing.T) {
+		generator := newIndexedGenerator(t, t.TempDir())
+		path := generateTextReport(t, generator, 1, "RPT-1-100")
+
+		indexed, ok := generator.index.lookup("RPT-1-100")
+		require.True(t, ok)
+		assert.Equal(t, path, indexed)
+
+		found, err := generator.FindReport("RPT-1-100")
+		require.NoError(t, err)
+		assert.Equal(t, path, found)
+	})
+
+	t.Run("Rebuild indexes existing reports", func(t *testing.T) {
+		dir := t.TempDir()
+		first := generateTextReport(t, newTestGeneratorIn(t, dir), 1, "RPT-1-100")
+		second := generateTextReport(t, newTestGeneratorIn(t, dir), 2, "RPT-2-100")
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("Report ID: RPT-3-100"), 0644))
+
+		generator := newIndexedGenerator(t, dir)
+		count, err := generator.RebuildIndex()
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		for reportID, want := range map[string]string{"RPT-1-100": first, "RPT-2-100": second} {
+			indexed, ok := generator.index.lookup(reportID)
+			require.True(t, ok, reportID)
+			assert.Equal(t, want, indexed)
+		}
+	})
+
+	t.Run("Stale entries fall back to scanning", func(t *testing.T) {
+		generator := newIndexedGenerator(t, t.TempDir())
+		path := generateTextReport(t, generator, 1, "RPT-1-100")
+		missing := filepath.Join(generator.outputDir, "student_report_1_missing.txt")
+		generator.index.add("RPT-1-100", missing)
+
+		found, err := generator.FindReport("RPT-1-100")
+
+		require.NoError(t, err)
+		assert.Equal(t, path, found)
+		indexed, ok := generator.index.lookup("RPT-1-100")
+		require.True(t, ok)
+		assert.Equal(t, path, indexed)
+	})
+
+	t.Run("Reports missing from the index are found by scanning", func(t *testing.T) {
+		dir := t.TempDir()
+		generator := newIndexedGenerator(t, dir)
+		path := generateTextReport(t, newTestGeneratorIn(t, dir), 1, "RPT-1-100")
+
+		found, err := generator.FindReport("RPT-1-100")
+
+		require.NoError(t, err)
+		assert.Equal(t, path, found)
+		_, ok := generator.index.lookup("RPT-1-100")
+		assert.True(t, ok)
+	})
+
+	t.Run("Cleanup removes deleted reports", func(t *testing.T) {
+		generator := newIndexedGenerator(t, t.TempDir())
+		generator.config.Cleanup = true
+		generator.config.CleanupAfter = time.Hour
+		path := generateTextReport(t, generator, 1, "RPT-1-100")
+		oldTime := time.Now().Add(-2 * time.Hour)
+		require.NoError(t, os.Chtimes(path, oldTime, oldTime))
+
+		summary, err := generator.CleanupOldReports(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, summary.Deleted)
+		_, ok := generator.index.lookup("RPT-1-100")
+		assert.False(t, ok)
+		_, err = generator.FindReport("RPT-1-100")
+		assert.ErrorIs(t, err, ErrReportNotFound)
+	})
+
+	t.Run("Disabled index", func(t *testing.T) {
+		generator := newTestGenerator(t)
+		path := generateTextReport(t, generator, 1, "RPT-1-100")
+
+		_, err := generator.RebuildIndex()
+		assert.ErrorIs(t, err, ErrIndexDisabled)
+
+		found, err := generator.FindReport("RPT-1-100")
+		require.NoError(t, err)
+		assert.Equal(t, path, found)
+	})
+
+	t.Run("Concurrent generation during rebuilds", func(t *testing.T) {
+		generator := newIndexedGenerator(t, t.TempDir())
+
+		const reports = 20
+		var wg sync.WaitGroup
+		for i := 1; i <= reports; i++ {
+			wg.Add(1)
+			go func(id int) {
+				defer wg.Done()
+				generateTextReport(t, generator, id, fmt.Sprintf("RPT-%d-100", id))
+			}(i)
+		}
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := generator.RebuildIndex()
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, reports, generator.index.size())
+		for i := 1; i <= reports; i++ {
+			_, ok := generator.index.lookup(fmt.Sprintf("RPT-%d-100", i))
+			assert.True(t, ok, i)
+		}
+	})
+}
+
+func TestReportIndex_ChangesDuringRebuild(t *testing.T) {
+	index := newReportIndex()
+	index.add("RPT-1", "/reports/one.txt")
+	index.add("RPT-2", "/reports/two.txt")
+
+	index.startRebuild()
+	index.add("RPT-3", "/reports/three.txt")
+	index.removePath("/reports/two.txt")
+	index.finishRebuild(map[string]string{
+		"RPT-1": "/reports/one.txt",
+		"RPT-2": "/reports/two.txt",
+	}, nil)
+
+	for reportID, want := range map[string]bool{"RPT-1": true, "RPT-2": false, "RPT-3": true} {
+		_, ok := index.lookup(reportID)
+		assert.Equal(t, want, ok, reportID)
+	}
+
+	index.startRebuild()
+	index.finishRebuild(nil, os.ErrPermission)
+	assert.Equal(t, 2, index.size())
+}
+
+// newTestGeneratorIn returns a generator without an index writing to dir
+func newTestGeneratorIn(t *testing.T, dir string) *Generator {
+	t.Helper()
+
+	generator, err := NewGenerator(&config.ReportConfig{OutputDir: dir, MaxFileSize: 10 * 1024 * 1024})
+	require.NoError(t, err)
+
+	return generator
+}
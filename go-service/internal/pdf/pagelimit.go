@@ -0,0 +1,82 @@
+package pdf
+
+import (
+	"errors"
+	"fmt"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// ErrPageLimitExceeded is returned when a report would exceed the configured
+// page limit and the limit mode is config.PageLimitFail
+var ErrPageLimitExceeded = errors.New("report exceeds maximum page count")
+
+// truncationNotice is printed on the last page of a truncated report
+const truncationNotice = "Content truncated: this report exceeded the maximum of %d pages."
+
+// limitPages stops rendering once the document reaches the configured page
+// limit. Instead of breaking onto a new page, the truncation notice is drawn
+// in the bottom margin and the document's error is set so that any remaining
+// content is dropped; checkPageLimit then decides what to do with it.
+// Explicit page starts go through addPage, which stops the same way.
+func (g *Generator) limitPages(pdf *gofpdf.Fpdf) {
+	if g.config.MaxPages <= 0 {
+		return
+	}
+
+	pdf.SetAcceptPageBreakFunc(func() bool {
+		if pdf.PageNo() < g.config.MaxPages {
+			return true
+		}
+
+		g.stopAtPageLimit(pdf)
+		return false
+	})
+}
+
+// addPage starts a new page, unless the document already has the maximum
+// number of pages
+func (g *Generator) addPage(pdf *gofpdf.Fpdf) {
+	if g.config.MaxPages > 0 && pdf.PageNo() >= g.config.MaxPages {
+		if !pdf.Err() {
+			g.stopAtPageLimit(pdf)
+		}
+		return
+	}
+	pdf.AddPage()
+}
+
+// stopAtPageLimit draws the truncation notice on the current page, in
+// truncate mode, and sets the document's error to ErrPageLimitExceeded
+func (g *Generator) stopAtPageLimit(pdf *gofpdf.Fpdf) {
+	if g.config.PageLimitMode != config.PageLimitFail {
+		_, pageHeight := pdf.GetPageSize()
+		leftMargin, _, _, bottomMargin := pdf.GetMargins()
+		pdf.SetFont("Arial", "B", 9)
+		pdf.SetTextColor(180, 0, 0)
+		pdf.Text(leftMargin, pageHeight-bottomMargin+6, fmt.Sprintf(truncationNotice, g.config.MaxPages))
+	}
+
+	pdf.SetError(ErrPageLimitExceeded)
+}
+
+// checkPageLimit handles a document that hit the page limit. In truncate
+// mode the document is kept and metadata is marked as truncated; in fail
+// mode ErrPageLimitExceeded is returned. Other document errors are left for
+// savePDF to report.
+func (g *Generator) checkPageLimit(pdf *gofpdf.Fpdf, metadata *models.ReportMetadata) error {
+	if !errors.Is(pdf.Error(), ErrPageLimitExceeded) {
+		return nil
+	}
+
+	if g.config.PageLimitMode == config.PageLimitFail {
+		return fmt.Errorf("%w (%d pages)", ErrPageLimitExceeded, g.config.MaxPages)
+	}
+
+	pdf.ClearError()
+	metadata.Truncated = true
+	return nil
+}
@@ -0,0 +1,184 @@
+package pdf
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// largeMetadata returns metadata with enough extra fields to span dozens of pages
+func largeMetadata() *models.ReportMetadata {
+	metadata := testMetadata()
+	for i := 1; i <= 2000; i++ {
+		metadata.AddExtraField(fmt.Sprintf("Course %d", i), "Completed")
+	}
+	return metadata
+}
+
+func pageCount(t *testing.T, path string) int {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return len(pageContentsPattern.FindAll(data, -1))
+}
+
+func TestGenerator_PageLimit(t *testing.T) {
+	student := &models.Student{ID: 1, Name: "John Doe"}
+
+	tests := []struct {
+		name              string
+		maxPages          int
+		mode              string
+		metadata          *models.ReportMetadata
+		expectedError     bool
+		expectedTruncated bool
+		expectedPages     int
+	}{
+		{
+			name:              "Large report truncated at limit",
+			maxPages:          3,
+			mode:              config.PageLimitTruncate,
+			metadata:          largeMetadata(),
+			expectedTruncated: true,
+			expectedPages:     3,
+		},
+		{
+			name:          "Large report fails in fail mode",
+			maxPages:      3,
+			mode:          config.PageLimitFail,
+			metadata:      largeMetadata(),
+			expectedError: true,
+		},
+		{
+			name:     "Small report within limit",
+			maxPages: 3,
+			mode:     config.PageLimitFail,
+			metadata: testMetadata(),
+		},
+		{
+			name:     "Zero disables the limit",
+			maxPages: 0,
+			mode:     config.PageLimitFail,
+			metadata: largeMetadata(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator, err := NewGenerator(&config.ReportConfig{
+				OutputDir:     t.TempDir(),
+				MaxFileSize:   10 * 1024 * 1024,
+				MaxPages:      tt.maxPages,
+				PageLimitMode: tt.mode,
+			})
+			require.NoError(t, err)
+
+			path, err := generator.GenerateStudentReport(student, tt.metadata)
+
+			if tt.expectedError {
+				assert.ErrorIs(t, err, ErrPageLimitExceeded)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedTruncated, tt.metadata.Truncated)
+
+			text, err := ExtractText(path)
+			require.NoError(t, err)
+
+			if tt.expectedTruncated {
+				assert.Equal(t, tt.expectedPages, pageCount(t, path))
+				assert.Contains(t, text, fmt.Sprintf(truncationNotice, tt.maxPages))
+				assert.NotContains(t, text, "Course 2000: Completed")
+			} else {
+				assert.NotContains(t, text, "Content truncated")
+			}
+		})
+	}
+}
+
+func TestGenerator_PageLimit_ExplicitPages(t *testing.T) {
+	household := func() []models.HouseholdMember {
+		members := make([]models.HouseholdMember, 0, 5)
+		for id := 1; id <= 5; id++ {
+			members = append(members, models.HouseholdMember{
+				Student:  &models.Student{ID: id, Name: fmt.Sprintf("Student %d", id)},
+				Metadata: testMetadata(),
+			})
+		}
+		return members
+	}
+	withCoverLetter := func() *models.ReportMetadata {
+		metadata := testMetadata()
+		metadata.CoverLetter = "Dear parent,\n\nPlease find the report attached."
+		return metadata
+	}
+
+	tests := []struct {
+		name          string
+		mode          string
+		generate      func(g *Generator, metadata *models.ReportMetadata) (string, error)
+		metadata      *models.ReportMetadata
+		expectedError bool
+	}{
+		{
+			name: "Household truncated at limit",
+			mode: config.PageLimitTruncate,
+			generate: func(g *Generator, metadata *models.ReportMetadata) (string, error) {
+				return g.GenerateHouseholdReport(household(), nil, metadata)
+			},
+			metadata: testMetadata(),
+		},
+		{
+			name: "Household fails in fail mode",
+			mode: config.PageLimitFail,
+			generate: func(g *Generator, metadata *models.ReportMetadata) (string, error) {
+				return g.GenerateHouseholdReport(household(), nil, metadata)
+			},
+			metadata:      testMetadata(),
+			expectedError: true,
+		},
+		{
+			name: "Cover letter truncated at limit",
+			mode: config.PageLimitTruncate,
+			generate: func(g *Generator, metadata *models.ReportMetadata) (string, error) {
+				return g.GenerateStudentReport(&models.Student{ID: 1, Name: "John Doe"}, metadata)
+			},
+			metadata: withCoverLetter(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator, err := NewGenerator(&config.ReportConfig{
+				OutputDir:     t.TempDir(),
+				MaxFileSize:   10 * 1024 * 1024,
+				MaxPages:      1,
+				PageLimitMode: tt.mode,
+			})
+			require.NoError(t, err)
+
+			path, err := tt.generate(generator, tt.metadata)
+
+			if tt.expectedError {
+				assert.ErrorIs(t, err, ErrPageLimitExceeded)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, tt.metadata.Truncated)
+			assert.Equal(t, 1, pageCount(t, path))
+
+			text, err := ExtractText(path)
+			require.NoError(t, err)
+			assert.Contains(t, text, fmt.Sprintf(truncationNotice, 1))
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package pdf
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+)
+
+// DefaultTextWidth is the column plain-text reports wrap at when none is configured
+const DefaultTextWidth = 80
+
+// GenerateStudentTextReport generates a plain-text version of the student's
+// report for screen readers and low-bandwidth delivery. It holds the same
+// sections as the PDF, wrapped at the configured text width.
+func (g *Generator) GenerateStudentTextReport(student *models.Student, metadata *models.ReportMetadata) (string, error) {
+	if student == nil {
+		return "", fmt.Errorf("student cannot be nil")
+	}
+
+	if metadata == nil {
+		metadata = &models.ReportMetadata{
+			GeneratedAt: g.now(),
+			GeneratedBy: "System",
+			ReportID:    StudentReportID(student.ID, g.now(), config.ReportFormatText),
+		}
+	}
+
+	filename := g.studentReportFilename(student, ".txt")
+
+	return g.writeReportFile(filename, metadata, func(filename string) (string, error) {
+		text := g.renderStudentText(student, metadata)
+		return g.saveReportFile(filename, "text report", func(w io.Writer) error {
+			_, err := io.WriteString(w, text)
+			return err
+		})
+	})
+}
+
+// textWidth returns the configured column width of plain-text reports
+func (g *Generator) textWidth() int {
+	if g.config.TextWidth > 0 {
+		return g.config.TextWidth
+	}
+	return DefaultTextWidth
+}
+
+// renderStudentText renders a student report as plain text
+func (g *Generator) renderStudentText(student *models.Student, metadata *models.ReportMetadata) string {
+	w := &textWriter{width: g.textWidth()}
+
+	if metadata.CoverLetter != "" {
+		for _, paragraph := range strings.Split(strings.ReplaceAll(metadata.CoverLetter, "\r\n", "\n"), "\n") {
+			w.wrapped(paragraph)
+		}
+		w.blank()
+		w.rule('=')
+		w.blank()
+	}
+
+	w.centered(reportTitle)
+	if watermark := g.reportWatermark(metadata); watermark != "" {
+		w.centered(watermark)
+	}
+	w.blank()
+	for _, line := range reportDetailLines(metadata) {
+		w.wrapped(line)
+	}
+
+	for _, section := range g.studentSections(student, metadata) {
+		w.blank()
+		w.heading(section.Title, '=')
+		for i, group := range section.Groups {
+			if i > 0 {
+				w.blank()
+			}
+			if group.Title != "" {
+				w.heading(group.Title, '-')
+			}
+			w.rows(group.Rows)
+		}
+	}
+
+	w.blank()
+	w.rule('-')
+	for _, line := range reportFooterLines(metadata) {
+		w.centered(line)
+	}
+
+	return w.String()
+}
+
+// textWriter lays out plain-text report lines within a column width
+type textWriter struct {
+	strings.Builder
+	width int
+}
+
+// textLength measures plain text in characters
+func textLength(s string) float64 {
+	return float64(utf8.RuneCountInString(s))
+}
+
+// line writes text as a line of its own with trailing spaces removed
+func (w *textWriter) line(text string) {
+	w.WriteString(strings.TrimRight(text, " "))
+	w.WriteByte('\n')
+}
+
+// blank writes an empty line
+func (w *textWriter) blank() {
+	w.WriteByte('\n')
+}
+
+// rule writes a full-width line of c
+func (w *textWriter) rule(c rune) {
+	w.line(strings.Repeat(string(c), w.width))
+}
+
+// wrapped writes text wrapped to the width. An empty text writes a blank line.
+func (w *textWriter) wrapped(text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		w.blank()
+		return
+	}
+	for _, line := range wrapMeasured(text, float64(w.width), textLength) {
+		w.line(line)
+	}
+}
+
+// centered writes text centered within the width, wrapping it if needed
+func (w *textWriter) centered(text string) {
+	for _, line := range wrapMeasured(text, float64(w.width), textLength) {
+		padding := (w.width - utf8.RuneCountInString(line)) / 2
+		w.line(strings.Repeat(" ", padding) + line)
+	}
+}
+
+// heading writes title underlined with c
+func (w *textWriter) heading(title string, c rune) {
+	w.wrapped(title)
+	length := utf8.RuneCountInString(title)
+	if length > w.width {
+		length = w.width
+	}
+	w.line(strings.Repeat(string(c), length))
+}
+
+// rows writes labelled values with the values aligned in a column. Values
+// wrap within their column; when labels leave too little room, values are
+// written indented below their labels instead.
+func (w *textWriter) rows(rows []reportRow) {
+	labelWidth := 0
+	for _, row := range rows {
+		if n := utf8.RuneCountInString(row.Label) + 2; n > labelWidth {
+			labelWidth = n
+		}
+	}
+
+	valueWidth := w.width - labelWidth
+	if valueWidth < w.width/3 {
+		const indent = "    "
+		for _, row := range rows {
+			w.wrapped(row.Label + ":")
+			for _, line := range wrapMeasured(row.Value, float64(w.width-len(indent)), textLength) {
+				w.line(indent + line)
+			}
+		}
+		return
+	}
+
+	for _, row := range rows {
+		label := row.Label + ":"
+		label += strings.Repeat(" ", labelWidth-utf8.RuneCountInString(label))
+		lines := wrapMeasured(row.Value, float64(valueWidth), textLength)
+		if len(lines) == 0 {
+			lines = []string{""}
+		}
+		w.line(label + lines[0])
+		for _, line := range lines[1:] {
+			w.line(strings.Repeat(" ", labelWidth) + line)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package pdf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_GenerateStudentTextReport(t *testing.T) {
+	address := "Flat 12, Riverside Apartments, 221 Long Street, North District, Springfield"
+	student := &models.Student{ID: 1, Name: "John Doe", Email: "john@example.com", CurrentAddress: &address}
+
+	metadata := testMetadata()
+	metadata.OnBehalfOf = "Ms. Smith"
+	metadata.AddExtraField("Bus Route", "42")
+
+	tests := []struct {
+		name  string
+		width int
+	}{
+		{name: "Default width", width: 0},
+		{name: "Narrow width", width: 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := newTestGenerator(t)
+			generator.config.TextWidth = tt.width
+
+			path, err := generator.GenerateStudentTextReport(student, metadata)
+			require.NoError(t, err)
+			assert.Equal(t, ".txt", filepath.Ext(path))
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			text := string(data)
+
+			width := tt.width
+			if width == 0 {
+				width = DefaultTextWidth
+			}
+			for _, line := range strings.Split(text, "\n") {
+				assert.LessOrEqual(t, utf8.RuneCountInString(line), width, line)
+			}
+
+			for _, want := range []string{
+				"Student Information Report",
+				"Report ID: RPT-1",
+				"Generated by: Test",
+				"On behalf of: Ms. Smith",
+				"Basic Information\n=================",
+				"Father's Information\n--------------------",
+				"Bus Route:",
+				"Riverside",
+				"This report is confidential",
+			} {
+				assert.Contains(t, text, want)
+			}
+
+			found, err := generator.FindReport("RPT-1")
+			require.NoError(t, err)
+			assert.Equal(t, path, found)
+		})
+	}
+}
+
+func TestTextWriter_Rows(t *testing.T) {
+	w := &textWriter{width: 40}
+	w.rows([]reportRow{
+		{"ID", "1"},
+		{"Current Address", "221 Long Street, North District, Springfield"},
+	})
+
+	assert.Equal(t, ""+
+		"ID:              1\n"+
+		"Current Address: 221 Long Street, North\n"+
+		"                 District, Springfield\n",
+		w.String())
+}
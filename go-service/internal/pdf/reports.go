@@ -0,0 +1,134 @@
+package pdf
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"student-report-service/internal/config"
+)
+
+// ErrReportNotFound is returned when no report file carries the requested report ID
+var ErrReportNotFound = errors.New("report not found")
+
+// textReportIDSuffix ends the report IDs of plain-text reports, so that a
+// text and a PDF report of a student generated in the same second differ
+const textReportIDSuffix = "-TXT"
+
+// StudentReportID returns the report ID of a student report generated at
+// the given time in format
+func StudentReportID(studentID int, generatedAt time.Time, format string) string {
+	reportID := fmt.Sprintf("RPT-%d-%d", studentID, generatedAt.Unix())
+	if format == config.ReportFormatText {
+		reportID += textReportIDSuffix
+	}
+	return reportID
+}
+
+// reportFileExtensions are the extensions of the report files the generator writes
+var reportFileExtensions = []string{".pdf", ".txt"}
+
+// isReportFile reports whether name is a generated report. Any PDF counts,
+// but text files only when named like a student report, so that notes kept
+// in the output directory are left alone.
+func isReportFile(name string) bool {
+	switch filepath.Ext(name) {
+	case ".pdf":
+		return true
+	case ".txt":
+		return strings.HasPrefix(name, "student_report_")
+	default:
+		return false
+	}
+}
+
+// FindReport returns the path of the report with the given ID in the output
+// directory. Indexed reports are returned directly; others are found by
+// matching the "Report ID" line of the report files' text.
+func (g *Generator) FindReport(reportID string) (string, error) {
+	if reportID == "" {
+		return "", fmt.Errorf("invalid report ID: empty")
+	}
+	if path, ok := g.indexedReport(reportID); ok {
+		return path, nil
+	}
+
+	var paths []string
+	for _, ext := range reportFileExtensions {
+		matches, err := filepath.Glob(filepath.Join(g.outputDir, reportFilePrefix(reportID)+"*"+ext))
+		if err != nil {
+			return "", fmt.Errorf("failed to list reports: %w", err)
+		}
+		paths = append(paths, matches...)
+	}
+
+	want := reportIDLinePrefix + reportID
+	for _, path := range paths {
+		lines, err := reportLines(path)
+		if err != nil {
+			continue
+		}
+		for _, line := range lines {
+			if line == want {
+				g.indexReport(reportID, path)
+				return path, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrReportNotFound, reportID)
+}
+
+// reportFilePrefix narrows the files that can hold a report ID. Student
+// reports are "RPT-<student>-..." and household reports "RPT-HH-<first student>-...",
+// and their file names start with the same student ID.
+func reportFilePrefix(reportID string) string {
+	parts := strings.Split(reportID, "-")
+	switch {
+	case len(parts) >= 3 && parts[0] == "RPT" && parts[1] == "HH":
+		return fmt.Sprintf("household_report_%s_", parts[2])
+	case len(parts) >= 2 && parts[0] == "RPT":
+		return fmt.Sprintf("student_report_%s_", parts[1])
+	default:
+		return ""
+	}
+}
+
+// ReportText returns the text content of a report file in reading order.
+// PDFs are read through their text layer and other files are read as plain
+// text. A report without a text layer yields an empty string.
+func ReportText(path string) (string, error) {
+	lines, err := reportLines(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// reportLines returns the non-empty text lines of a report file
+func reportLines(path string) ([]string, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".pdf" {
+		lines, err := ExtractTextLines(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract text from %s: %w", path, err)
+		}
+		return lines, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report %s: %w", path, err)
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
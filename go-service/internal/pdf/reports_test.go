@@ -0,0 +1,76 @@
+package pdf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"student-report-service/internal/models"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_FindReport(t *testing.T) {
+	generator := newTestGenerator(t)
+	student := &models.Student{ID: 7, Name: "John Doe"}
+
+	first := testMetadata()
+	first.ReportID = "RPT-7-1705314600"
+	firstPath, err := generator.GenerateStudentReport(student, first)
+	require.NoError(t, err)
+
+	second := testMetadata()
+	second.ReportID = "RPT-7-1705314600-2"
+	secondPath, err := generator.GenerateStudentReport(&models.Student{ID: 7, Name: "John A Doe"}, second)
+	require.NoError(t, err)
+
+	path, err := generator.FindReport("RPT-7-1705314600")
+	require.NoError(t, err)
+	assert.Equal(t, firstPath, path)
+
+	path, err = generator.FindReport("RPT-7-1705314600-2")
+	require.NoError(t, err)
+	assert.Equal(t, secondPath, path)
+
+	_, err = generator.FindReport("RPT-8-1705314600")
+	assert.ErrorIs(t, err, ErrReportNotFound)
+}
+
+func TestReportText(t *testing.T) {
+	t.Run("PDF text layer in reading order", func(t *testing.T) {
+		path := generateTestReport(t, &models.Student{ID: 1, Name: "John Doe", Email: "john@example.com"})
+
+		text, err := ReportText(path)
+		require.NoError(t, err)
+
+		assert.Less(t, strings.Index(text, "Student Information Report"), strings.Index(text, "Full Name: John Doe"))
+		assert.Less(t, strings.Index(text, "Full Name: John Doe"), strings.Index(text, "Primary Email: john@example.com"))
+	})
+
+	t.Run("Text report lines are trimmed", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "report.txt")
+		require.NoError(t, os.WriteFile(path, []byte("Student Report\r\n\r\n  Name: John  \n"), 0644))
+
+		text, err := ReportText(path)
+		require.NoError(t, err)
+		assert.Equal(t, "Student Report\nName: John", text)
+	})
+
+	t.Run("PDF without a text layer", func(t *testing.T) {
+		doc := gofpdf.New("P", "mm", "A4", "")
+		doc.SetCreationDate(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+		doc.AddPage()
+		doc.Rect(20, 20, 50, 50, "D")
+
+		path := filepath.Join(t.TempDir(), "scan.pdf")
+		require.NoError(t, doc.OutputFileAndClose(path))
+
+		text, err := ReportText(path)
+		require.NoError(t, err)
+		assert.Empty(t, text)
+	})
+}
@@ -0,0 +1,131 @@
+package pdf
+
+import (
+	"fmt"
+
+	"student-report-service/internal/models"
+)
+
+// reportRow is a labelled value in a report section
+type reportRow struct {
+	Label string
+	Value string
+}
+
+// reportGroup is a run of rows within a section, with an optional subheading
+type reportGroup struct {
+	Title string
+	Rows  []reportRow
+}
+
+// reportSection is a headed section of a student report. Sections describe
+// what a report contains independently of its format, so every format
+// renders the same data.
+type reportSection struct {
+	Title  string
+	Groups []reportGroup
+}
+
+// reportTitle is the heading of every student report
+const reportTitle = "Student Information Report"
+
+// reportFooterLines close every student report
+func reportFooterLines(metadata *models.ReportMetadata) []string {
+	return []string{
+		"This report is confidential and intended for authorized personnel only.",
+		fmt.Sprintf("Generated on %s", metadata.GeneratedAt.Format("January 2, 2006")),
+		"Student Management System",
+	}
+}
+
+// reportDetailLines are the report's own details shown under the title
+func reportDetailLines(metadata *models.ReportMetadata) []string {
+	lines := []string{
+		fmt.Sprintf("Report ID: %s", metadata.ReportID),
+		fmt.Sprintf("Generated: %s", metadata.GeneratedAt.Format("January 2, 2006 at 15:04 MST")),
+		fmt.Sprintf("Generated by: %s", metadata.GeneratedBy),
+	}
+	if metadata.OnBehalfOf != "" {
+		lines = append(lines, fmt.Sprintf("On behalf of: %s", metadata.OnBehalfOf))
+	}
+	return lines
+}
+
+// reportWatermark returns the report's watermark, falling back to the configured text
+func (g *Generator) reportWatermark(metadata *models.ReportMetadata) string {
+	if metadata.Watermark != nil {
+		return *metadata.Watermark
+	}
+	return g.config.WatermarkText
+}
+
+// studentSections lists the sections of a student's report in order
+func (g *Generator) studentSections(student *models.Student, metadata *models.ReportMetadata) []reportSection {
+	basic := []reportRow{
+		{"Student ID", fmt.Sprintf("%d", student.ID)},
+		{"Full Name", student.FormatName()},
+		{"Email Address", student.FormatEmail()},
+		{"System Access", g.formatBool(student.SystemAccess)},
+	}
+	if student.Gender != nil {
+		basic = append(basic, reportRow{"Gender", models.SafeString(student.Gender, "Not specified")})
+	}
+	if student.DOB != nil {
+		basic = append(basic, reportRow{"Date of Birth", models.SafeString(student.DOB, "Not specified")})
+	}
+	if student.Phone != nil {
+		basic = append(basic, reportRow{"Phone Number", models.SafeString(student.Phone, "Not provided")})
+	}
+
+	roll := "Not assigned"
+	if student.Roll != nil {
+		roll = fmt.Sprintf("%d", models.SafeInt(student.Roll, 0))
+	}
+	academic := []reportRow{
+		{"Class", models.SafeString(student.Class, "Not assigned")},
+		{"Section", models.SafeString(student.Section, "Not assigned")},
+		{"Roll Number", roll},
+		{"Admission Date", models.SafeString(student.AdmissionDate, "Not recorded")},
+	}
+	if student.ReporterName != nil {
+		academic = append(academic, reportRow{"Reporter", models.SafeString(student.ReporterName, "System")})
+	}
+
+	sections := []reportSection{
+		{Title: "Basic Information", Groups: []reportGroup{{Rows: basic}}},
+		{Title: "Contact Information", Groups: []reportGroup{{Rows: []reportRow{
+			{"Primary Email", student.FormatEmail()},
+			{"Phone Number", models.SafeString(student.Phone, "Not provided")},
+		}}}},
+		{Title: "Family & Guardian Information", Groups: []reportGroup{
+			{Title: "Father's Information", Rows: []reportRow{
+				{"Father's Name", models.SafeString(student.FatherName, "Not provided")},
+				{"Father's Phone", models.SafeString(student.FatherPhone, "Not provided")},
+			}},
+			{Title: "Mother's Information", Rows: []reportRow{
+				{"Mother's Name", models.SafeString(student.MotherName, "Not provided")},
+				{"Mother's Phone", models.SafeString(student.MotherPhone, "Not provided")},
+			}},
+			{Title: "Guardian Information", Rows: []reportRow{
+				{"Guardian's Name", models.SafeString(student.GuardianName, "Not provided")},
+				{"Guardian's Phone", models.SafeString(student.GuardianPhone, "Not provided")},
+				{"Relation to Student", models.SafeString(student.RelationOfGuardian, "Not specified")},
+			}},
+		}},
+		{Title: "Address Information", Groups: []reportGroup{{Rows: []reportRow{
+			{"Current Address", models.SafeString(student.CurrentAddress, "Not provided")},
+			{"Permanent Address", models.SafeString(student.PermanentAddress, "Not provided")},
+		}}}},
+		{Title: "Academic Information", Groups: []reportGroup{{Rows: academic}}},
+	}
+
+	if len(metadata.ExtraFields) > 0 {
+		extra := make([]reportRow, 0, len(metadata.ExtraFields))
+		for _, field := range metadata.ExtraFields {
+			extra = append(extra, reportRow{field.Label, field.Value})
+		}
+		sections = append(sections, reportSection{Title: "Additional Information", Groups: []reportGroup{{Rows: extra}}})
+	}
+
+	return sections
+}
@@ -0,0 +1,117 @@
+package pdf
+
+import (
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Text overflow modes for values wider than their area
+const (
+	TextOverflowWrap     = "wrap"
+	TextOverflowTruncate = "truncate"
+)
+
+// ellipsis marks truncated text. The core fonts are not UTF-8 aware, so the
+// single-character ellipsis can't be used.
+const ellipsis = "..."
+
+// fitText splits text into lines no wider than width using the current font,
+// either wrapping or truncating with an ellipsis depending on configuration
+func (g *Generator) fitText(pdf *gofpdf.Fpdf, text string, width float64) []string {
+	if pdf.GetStringWidth(text) <= width {
+		return []string{text}
+	}
+
+	if strings.EqualFold(g.config.TextOverflow, TextOverflowTruncate) {
+		return []string{truncateText(pdf, text, width)}
+	}
+
+	return wrapText(pdf, text, width)
+}
+
+// truncateText shortens text so that it, plus an ellipsis, fits within width
+func truncateText(pdf *gofpdf.Fpdf, text string, width float64) string {
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimRight(string(runes), " ") + ellipsis
+		if pdf.GetStringWidth(candidate) <= width {
+			return candidate
+		}
+	}
+	return ellipsis
+}
+
+// wrapText breaks text into lines at spaces and after hyphens, splitting
+// words that are wider than a full line on their own
+func wrapText(pdf *gofpdf.Fpdf, text string, width float64) []string {
+	return wrapMeasured(text, width, pdf.GetStringWidth)
+}
+
+// wrapMeasured is wrapText with the width of a string given by measure
+func wrapMeasured(text string, width float64, measure func(string) float64) []string {
+	var lines []string
+	line := ""
+
+	for _, token := range wrapTokens(text) {
+		candidate := line + token
+		if line == "" {
+			candidate = strings.TrimLeft(token, " ")
+		}
+
+		if measure(candidate) <= width {
+			line = candidate
+			continue
+		}
+
+		if line != "" {
+			lines = append(lines, strings.TrimRight(line, " "))
+		}
+		line = strings.TrimLeft(token, " ")
+
+		// A single token wider than the line is split by characters
+		for measure(line) > width {
+			runes := []rune(line)
+			n := len(runes) - 1
+			for n > 1 && measure(string(runes[:n])) > width {
+				n--
+			}
+			lines = append(lines, string(runes[:n]))
+			line = string(runes[n:])
+		}
+	}
+
+	if line = strings.TrimRight(line, " "); line != "" {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// wrapTokens splits text into pieces that may be placed on separate lines.
+// Spaces stay attached to the start of the following piece and hyphens to
+// the end of the preceding one.
+func wrapTokens(text string) []string {
+	var tokens []string
+	start := 0
+
+	for i, r := range text {
+		switch r {
+		case ' ':
+			if i > start && text[i-1] != ' ' {
+				tokens = append(tokens, text[start:i])
+				start = i
+			}
+		case '-':
+			tokens = append(tokens, text[start:i+1])
+			start = i + 1
+		}
+	}
+
+	if start < len(text) {
+		tokens = append(tokens, text[start:])
+	}
+
+	return tokens
+}
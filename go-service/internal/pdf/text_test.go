@@ -0,0 +1,105 @@
+package pdf
+
+import (
+	"strings"
+	"testing"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pathologicalStrings = map[string]string{
+	"Long hyphenated name": "Anna-Maria-Josefina-Gabriela-Theodora-Magdalena-Wilhelmina-Konstantina-Alexandra Smith-Jones",
+	"Unbroken string":      strings.Repeat("W", 300),
+	"Many short words":     strings.Repeat("Introduction to Advanced Topics ", 20),
+	"Repeated spaces":      "Course" + strings.Repeat(" ", 200) + "Title",
+}
+
+func newTextTestPDF() *gofpdf.Fpdf {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddPage()
+	pdf.SetFont("Arial", "", 10)
+	return pdf
+}
+
+func TestGenerator_FitText_Wrap(t *testing.T) {
+	g := &Generator{config: &config.ReportConfig{TextOverflow: TextOverflowWrap}}
+	pdf := newTextTestPDF()
+	const width = 120.0
+
+	for name, text := range pathologicalStrings {
+		t.Run(name, func(t *testing.T) {
+			lines := g.fitText(pdf, text, width)
+
+			require.NotEmpty(t, lines)
+			for _, line := range lines {
+				assert.LessOrEqual(t, pdf.GetStringWidth(line), width)
+			}
+
+			// Wrapping only drops the whitespace at line breaks
+			joined := strings.Join(lines, "")
+			assert.Equal(t, strings.ReplaceAll(text, " ", ""), strings.ReplaceAll(joined, " ", ""))
+		})
+	}
+}
+
+func TestGenerator_FitText_Truncate(t *testing.T) {
+	g := &Generator{config: &config.ReportConfig{TextOverflow: TextOverflowTruncate}}
+	pdf := newTextTestPDF()
+	const width = 120.0
+
+	for name, text := range pathologicalStrings {
+		t.Run(name, func(t *testing.T) {
+			lines := g.fitText(pdf, text, width)
+
+			require.Len(t, lines, 1)
+			assert.LessOrEqual(t, pdf.GetStringWidth(lines[0]), width)
+			assert.True(t, strings.HasSuffix(lines[0], ellipsis))
+		})
+	}
+}
+
+func TestGenerator_FitText_ShortTextUnchanged(t *testing.T) {
+	for _, mode := range []string{TextOverflowWrap, TextOverflowTruncate} {
+		g := &Generator{config: &config.ReportConfig{TextOverflow: mode}}
+		assert.Equal(t, []string{"John Doe"}, g.fitText(newTextTestPDF(), "John Doe", 120))
+	}
+}
+
+func TestGenerator_LongValues(t *testing.T) {
+	longName := pathologicalStrings["Long hyphenated name"]
+	longAddress := pathologicalStrings["Many short words"]
+
+	for _, mode := range []string{TextOverflowWrap, TextOverflowTruncate} {
+		t.Run(mode, func(t *testing.T) {
+			generator := newTestGenerator(t)
+			generator.config.TextOverflow = mode
+
+			metadata := testMetadata()
+			metadata.GeneratedBy = pathologicalStrings["Unbroken string"]
+
+			path, err := generator.GenerateStudentReport(&models.Student{
+				ID:             1,
+				Name:           longName,
+				CurrentAddress: &longAddress,
+			}, metadata)
+			require.NoError(t, err)
+
+			text, err := ExtractText(path)
+			require.NoError(t, err)
+
+			if mode == TextOverflowTruncate {
+				assert.Contains(t, text, "Full Name: Anna-Maria")
+				assert.Contains(t, text, ellipsis)
+				assert.NotContains(t, text, "Smith-Jones")
+			} else {
+				assert.Contains(t, text, "Smith-Jones")
+				assert.NotContains(t, text, ellipsis)
+			}
+		})
+	}
+}
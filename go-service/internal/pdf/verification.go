@@ -0,0 +1,131 @@
+package pdf
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrVerificationFailed is returned when a report does not match its
+// verification record or the record's signature is invalid
+var ErrVerificationFailed = errors.New("report verification failed")
+
+// ErrVerificationDisabled is returned when reports are verified without a
+// configured verification secret
+var ErrVerificationDisabled = errors.New("report verification is not configured")
+
+// verificationAlgorithm is the signature scheme recorded in verification records
+const verificationAlgorithm = "HMAC-SHA256"
+
+// VerificationRecord is a detached record that lets a report be verified
+// without parsing the PDF. The signature covers the report ID, content hash
+// and signing time.
+type VerificationRecord struct {
+	ReportID  string    `json:"report_id"`
+	SHA256    string    `json:"sha256"`
+	SignedAt  time.Time `json:"signed_at"`
+	Algorithm string    `json:"algorithm"`
+	Signature string    `json:"signature"`
+}
+
+// VerificationRecordPath returns where the verification record of a report
+// is saved. The record is named after the whole file name, extension
+// included, so the PDF and text reports of a student never share a record.
+func VerificationRecordPath(reportPath string) string {
+	return reportPath + ".verification.json"
+}
+
+// writeVerificationRecord signs the report at reportPath and saves its record alongside it
+func (g *Generator) writeVerificationRecord(reportPath, reportID string) error {
+	hash, err := fileSHA256(reportPath)
+	if err != nil {
+		return err
+	}
+
+	record := VerificationRecord{
+		ReportID:  reportID,
+		SHA256:    hash,
+		SignedAt:  g.now().UTC(),
+		Algorithm: verificationAlgorithm,
+	}
+	record.Signature = g.signRecord(&record)
+
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode verification record: %w", err)
+	}
+
+	if err := os.WriteFile(VerificationRecordPath(reportPath), data, 0644); err != nil {
+		return fmt.Errorf("failed to save verification record: %w", err)
+	}
+	return nil
+}
+
+// VerifyWithRecord checks a report against its detached verification record:
+// the record must be signed with the configured secret and the report's
+// content must match the recorded hash. A report without a record fails.
+func (g *Generator) VerifyWithRecord(reportPath, recordPath string) error {
+	if g.config.VerificationSecret == "" {
+		return ErrVerificationDisabled
+	}
+
+	data, err := os.ReadFile(recordPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: no verification record for %s", ErrVerificationFailed, filepath.Base(reportPath))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read verification record: %w", err)
+	}
+
+	var record VerificationRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return fmt.Errorf("invalid verification record %s: %w", recordPath, err)
+	}
+
+	if record.Algorithm != verificationAlgorithm {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrVerificationFailed, record.Algorithm)
+	}
+
+	signature, err := hex.DecodeString(record.Signature)
+	if err != nil || !hmac.Equal(signature, g.recordMAC(&record)) {
+		return fmt.Errorf("%w: invalid signature", ErrVerificationFailed)
+	}
+
+	hash, err := fileSHA256(reportPath)
+	if err != nil {
+		return err
+	}
+	if hash != record.SHA256 {
+		return fmt.Errorf("%w: report content does not match record %s", ErrVerificationFailed, record.ReportID)
+	}
+
+	return nil
+}
+
+// signRecord returns the hex signature of a verification record
+func (g *Generator) signRecord(record *VerificationRecord) string {
+	return hex.EncodeToString(g.recordMAC(record))
+}
+
+func (g *Generator) recordMAC(record *VerificationRecord) []byte {
+	mac := hmac.New(sha256.New, []byte(g.config.VerificationSecret))
+	fmt.Fprintf(mac, "%s\n%s\n%s", record.ReportID, record.SHA256, record.SignedAt.UTC().Format(time.RFC3339Nano))
+	return mac.Sum(nil)
+}
+
+// fileSHA256 returns the hex SHA-256 hash of a file's content
+func fileSHA256(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read report: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
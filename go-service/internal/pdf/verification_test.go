@@ -0,0 +1,186 @@
+package pdf
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newVerifyingGenerator(t *testing.T, dir, secret string) *Generator {
+	t.Helper()
+
+	generator, err := NewGenerator(&config.ReportConfig{
+		OutputDir:          dir,
+		MaxFileSize:        10 * 1024 * 1024,
+		Cleanup:            true,
+		CleanupAfter:       time.Hour,
+		VerificationSecret: secret,
+	})
+	require.NoError(t, err)
+
+	return generator
+}
+
+func TestGenerator_VerificationRecord(t *testing.T) {
+	student := &models.Student{ID: 1, Name: "John Doe"}
+
+	t.Run("Record verifies its report", func(t *testing.T) {
+		generator := newVerifyingGenerator(t, t.TempDir(), "secret")
+
+		reportPath, err := generator.GenerateStudentReport(student, testMetadata())
+		require.NoError(t, err)
+
+		recordPath := VerificationRecordPath(reportPath)
+		data, err := os.ReadFile(recordPath)
+		require.NoError(t, err)
+
+		var record VerificationRecord
+		require.NoError(t, json.Unmarshal(data, &record))
+		assert.Equal(t, "RPT-1", record.ReportID)
+		assert.Equal(t, "HMAC-SHA256", record.Algorithm)
+		assert.Len(t, record.SHA256, 64)
+
+		assert.NoError(t, generator.VerifyWithRecord(reportPath, recordPath))
+	})
+
+	t.Run("Modified report fails verification", func(t *testing.T) {
+		generator := newVerifyingGenerator(t, t.TempDir(), "secret")
+
+		reportPath, err := generator.GenerateStudentReport(student, testMetadata())
+		require.NoError(t, err)
+
+		file, err := os.OpenFile(reportPath, os.O_APPEND|os.O_WRONLY, 0644)
+		require.NoError(t, err)
+		_, err = file.WriteString("%% tampered\n")
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		err = generator.VerifyWithRecord(reportPath, VerificationRecordPath(reportPath))
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.ErrorContains(t, err, "does not match")
+	})
+
+	t.Run("Modified record fails verification", func(t *testing.T) {
+		generator := newVerifyingGenerator(t, t.TempDir(), "secret")
+
+		reportPath, err := generator.GenerateStudentReport(student, testMetadata())
+		require.NoError(t, err)
+		recordPath := VerificationRecordPath(reportPath)
+
+		data, err := os.ReadFile(recordPath)
+		require.NoError(t, err)
+		var record VerificationRecord
+		require.NoError(t, json.Unmarshal(data, &record))
+		record.ReportID = "RPT-2"
+		data, err = json.Marshal(record)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(recordPath, data, 0644))
+
+		err = generator.VerifyWithRecord(reportPath, recordPath)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.ErrorContains(t, err, "invalid signature")
+	})
+
+	t.Run("Different secret fails verification", func(t *testing.T) {
+		dir := t.TempDir()
+		reportPath, err := newVerifyingGenerator(t, dir, "secret").GenerateStudentReport(student, testMetadata())
+		require.NoError(t, err)
+
+		err = newVerifyingGenerator(t, dir, "other").VerifyWithRecord(reportPath, VerificationRecordPath(reportPath))
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+
+	t.Run("No record without a secret", func(t *testing.T) {
+		generator := newVerifyingGenerator(t, t.TempDir(), "")
+
+		reportPath, err := generator.GenerateStudentReport(student, testMetadata())
+		require.NoError(t, err)
+
+		assert.NoFileExists(t, VerificationRecordPath(reportPath))
+		assert.ErrorIs(t, generator.VerifyWithRecord(reportPath, VerificationRecordPath(reportPath)), ErrVerificationDisabled)
+	})
+
+	t.Run("No record for unsigned reports", func(t *testing.T) {
+		generator := newVerifyingGenerator(t, t.TempDir(), "secret")
+		metadata := testMetadata()
+		metadata.Unsigned = true
+
+		reportPath, err := generator.GenerateStudentReport(student, metadata)
+		require.NoError(t, err)
+
+		assert.NoFileExists(t, VerificationRecordPath(reportPath))
+	})
+
+	t.Run("Missing record fails verification", func(t *testing.T) {
+		dir := t.TempDir()
+		reportPath, err := newVerifyingGenerator(t, dir, "").GenerateStudentReport(student, testMetadata())
+		require.NoError(t, err)
+
+		err = newVerifyingGenerator(t, dir, "secret").VerifyWithRecord(reportPath, VerificationRecordPath(reportPath))
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.ErrorContains(t, err, "no verification record")
+	})
+
+	t.Run("PDF and text reports keep separate records", func(t *testing.T) {
+		generator := newVerifyingGenerator(t, t.TempDir(), "secret")
+		generator.SetClock(func() time.Time { return time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC) })
+
+		pdfPath, err := generator.GenerateStudentReport(student, nil)
+		require.NoError(t, err)
+		textPath, err := generator.GenerateStudentTextReport(student, nil)
+		require.NoError(t, err)
+
+		require.NotEqual(t, VerificationRecordPath(pdfPath), VerificationRecordPath(textPath))
+		assert.NoError(t, generator.VerifyWithRecord(pdfPath, VerificationRecordPath(pdfPath)))
+		assert.NoError(t, generator.VerifyWithRecord(textPath, VerificationRecordPath(textPath)))
+
+		pdfID, err := generator.FindReport("RPT-1-1705314600")
+		require.NoError(t, err)
+		assert.Equal(t, pdfPath, pdfID)
+		textID, err := generator.FindReport("RPT-1-1705314600-TXT")
+		require.NoError(t, err)
+		assert.Equal(t, textPath, textID)
+	})
+
+	t.Run("Record is signed at the generator's clock", func(t *testing.T) {
+		generator := newVerifyingGenerator(t, t.TempDir(), "secret")
+		signedAt := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+		generator.SetClock(func() time.Time { return signedAt })
+
+		reportPath, err := generator.GenerateStudentReport(student, testMetadata())
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(VerificationRecordPath(reportPath))
+		require.NoError(t, err)
+		var record VerificationRecord
+		require.NoError(t, json.Unmarshal(data, &record))
+		assert.True(t, signedAt.Equal(record.SignedAt))
+	})
+
+	t.Run("Cleanup removes the record with its report", func(t *testing.T) {
+		dir := t.TempDir()
+		generator := newVerifyingGenerator(t, dir, "secret")
+
+		reportPath, err := generator.GenerateStudentReport(student, testMetadata())
+		require.NoError(t, err)
+		old := time.Now().Add(-2 * time.Hour)
+		require.NoError(t, os.Chtimes(reportPath, old, old))
+
+		summary, err := generator.CleanupOldReports(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, summary.Deleted)
+
+		remaining, err := filepath.Glob(filepath.Join(dir, "*"))
+		require.NoError(t, err)
+		assert.Empty(t, remaining)
+	})
+}
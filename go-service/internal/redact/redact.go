@@ -0,0 +1,157 @@
+// Package redact scrubs student personal data from log output and error
+// messages, keeping numeric IDs so that entries can still be correlated.
+package redact
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// piiKeys are field, filter and query parameter names whose values are
+// personal data, lower-cased with underscores removed
+var piiKeys = map[string]bool{
+	"name":               true,
+	"studentname":        true,
+	"email":              true,
+	"phone":              true,
+	"dob":                true,
+	"fathername":         true,
+	"fatherphone":        true,
+	"mothername":         true,
+	"motherphone":        true,
+	"guardianname":       true,
+	"guardianphone":      true,
+	"currentaddress":     true,
+	"permanentaddress":   true,
+	"reportername":       true,
+	"relationofguardian": true,
+}
+
+var (
+	emailPattern      = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	jsonFieldPattern  = regexp.MustCompile(`"([A-Za-z]+)"\s*:\s*"((?:[^"\\]|\\.)*)"`)
+	queryParamPattern = regexp.MustCompile(`([?&])([A-Za-z]+)=([^&#\s"]*)`)
+	// Report file names embed the student's name between their ID and timestamp
+	reportFilePattern = regexp.MustCompile(`(student_report_\d+_)(.+?)(_\d{8}_\d{6})`)
+)
+
+func isPIIKey(key string) bool {
+	return piiKeys[strings.ReplaceAll(strings.ToLower(key), "_", "")]
+}
+
+// Redactor scrubs personal data when enabled and passes everything through
+// unchanged otherwise. A nil Redactor is disabled.
+type Redactor struct {
+	enabled bool
+}
+
+// New creates a redactor
+func New(enabled bool) *Redactor {
+	return &Redactor{enabled: enabled}
+}
+
+// Enabled reports whether personal data is being scrubbed
+func (r *Redactor) Enabled() bool {
+	return r != nil && r.enabled
+}
+
+// Hash replaces a value with a short stable hash, so equal values can still
+// be matched across log entries without revealing them
+func Hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return "pii:" + hex.EncodeToString(sum[:4])
+}
+
+// String scrubs email addresses, personal JSON fields and query parameters,
+// and the names embedded in report file names from s
+func (r *Redactor) String(s string) string {
+	if !r.Enabled() {
+		return s
+	}
+
+	s = reportFilePattern.ReplaceAllStringFunc(s, func(match string) string {
+		parts := reportFilePattern.FindStringSubmatch(match)
+		return parts[1] + Hash(parts[2]) + parts[3]
+	})
+
+	s = jsonFieldPattern.ReplaceAllStringFunc(s, func(match string) string {
+		parts := jsonFieldPattern.FindStringSubmatch(match)
+		if !isPIIKey(parts[1]) {
+			return match
+		}
+		return fmt.Sprintf(`"%s":"%s"`, parts[1], Hash(parts[2]))
+	})
+
+	s = queryParamPattern.ReplaceAllStringFunc(s, func(match string) string {
+		parts := queryParamPattern.FindStringSubmatch(match)
+		if !isPIIKey(parts[2]) {
+			return match
+		}
+		value, err := url.QueryUnescape(parts[3])
+		if err != nil {
+			value = parts[3]
+		}
+		return parts[1] + parts[2] + "=" + Hash(value)
+	})
+
+	return emailPattern.ReplaceAllStringFunc(s, Hash)
+}
+
+// Filters returns a copy of student list filters with personal values hashed
+func (r *Redactor) Filters(filters map[string]string) map[string]string {
+	if !r.Enabled() {
+		return filters
+	}
+
+	redacted := make(map[string]string, len(filters))
+	for key, value := range filters {
+		if isPIIKey(key) {
+			value = Hash(value)
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
+// Hook returns a logrus hook that scrubs the message and fields of every entry
+func (r *Redactor) Hook() logrus.Hook {
+	return &hook{redactor: r}
+}
+
+type hook struct {
+	redactor *Redactor
+}
+
+func (h *hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *hook) Fire(entry *logrus.Entry) error {
+	entry.Message = h.redactor.String(entry.Message)
+
+	for key, value := range entry.Data {
+		if isPIIKey(key) {
+			entry.Data[key] = Hash(fmt.Sprint(value))
+			continue
+		}
+
+		switch v := value.(type) {
+		case string:
+			entry.Data[key] = h.redactor.String(v)
+		case error:
+			entry.Data[key] = h.redactor.String(v.Error())
+		case map[string]string:
+			entry.Data[key] = h.redactor.Filters(v)
+		case fmt.Stringer:
+			entry.Data[key] = h.redactor.String(v.String())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package redact
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactorString(t *testing.T) {
+	redactor := New(true)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "email address",
+			input:    "failed to notify john.doe@example.com",
+			expected: "failed to notify " + Hash("john.doe@example.com"),
+		},
+		{
+			name:     "JSON name field",
+			input:    `{"id":42,"name":"John Doe","class":"10"}`,
+			expected: `{"id":42,"name":"` + Hash("John Doe") + `","class":"10"}`,
+		},
+		{
+			name:     "JSON guardian fields",
+			input:    `{"fatherName": "Richard Doe", "guardianPhone": "555-0100"}`,
+			expected: `{"fatherName":"` + Hash("Richard Doe") + `", "guardianPhone":"` + Hash("555-0100") + `"}`,
+		},
+		{
+			name:     "name query parameter",
+			input:    "GET /api/v1/students?class=10&name=John%20Doe",
+			expected: "GET /api/v1/students?class=10&name=" + Hash("John Doe"),
+		},
+		{
+			name:     "report file name",
+			input:    "reports/student_report_42_John_Doe_20240101_120000.pdf",
+			expected: "reports/student_report_42_" + Hash("John_Doe") + "_20240101_120000.pdf",
+		},
+		{
+			name:     "numeric IDs kept",
+			input:    "student 42 not found",
+			expected: "student 42 not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, redactor.String(tt.input))
+		})
+	}
+}
+
+func TestRedactorDisabled(t *testing.T) {
+	input := `{"name":"John Doe","email":"john.doe@example.com"}`
+
+	assert.Equal(t, input, New(false).String(input))
+
+	var redactor *Redactor
+	assert.False(t, redactor.Enabled())
+	assert.Equal(t, input, redactor.String(input))
+}
+
+func TestRedactorFilters(t *testing.T) {
+	filters := map[string]string{"name": "John", "class": "10"}
+
+	redacted := New(true).Filters(filters)
+
+	assert.Equal(t, map[string]string{"name": Hash("John"), "class": "10"}, redacted)
+	assert.Equal(t, "John", filters["name"], "original filters must not be modified")
+}
+
+func TestHash(t *testing.T) {
+	assert.Equal(t, Hash("John Doe"), Hash("John Doe"))
+	assert.NotEqual(t, Hash("John Doe"), Hash("Jane Doe"))
+	assert.Regexp(t, `^pii:[0-9a-f]{8}$`, Hash("John Doe"))
+}
+
+func TestHook(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.AddHook(New(true).Hook())
+	entries := test.NewLocal(logger)
+
+	endpoint, err := url.Parse("http://localhost:5007/api/v1/students?name=John")
+	require.NoError(t, err)
+
+	logger.WithFields(logrus.Fields{
+		"student_id":   42,
+		"student_name": "John Doe",
+		"name":         "John Doe",
+		"filters":      map[string]string{"email": "john@example.com"},
+		"url":          endpoint,
+	}).WithError(errors.New(`bad response: {"name":"John Doe"}`)).
+		Warn("Report failed for john@example.com")
+
+	entry := entries.LastEntry()
+	require.NotNil(t, entry)
+
+	assert.Equal(t, "Report failed for "+Hash("john@example.com"), entry.Message)
+	assert.Equal(t, 42, entry.Data["student_id"])
+	assert.Equal(t, Hash("John Doe"), entry.Data["student_name"])
+	assert.Equal(t, Hash("John Doe"), entry.Data["name"])
+	assert.Equal(t, map[string]string{"email": Hash("john@example.com")}, entry.Data["filters"])
+	assert.Equal(t, "http://localhost:5007/api/v1/students?name="+Hash("John"), entry.Data["url"])
+	assert.Equal(t, `bad response: {"name":"`+Hash("John Doe")+`"}`, entry.Data[logrus.ErrorKey])
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+)
+
+// renderCoverLetter renders the configured cover letter template for a student.
+//
+// Templates can use the student's ID, Name, Email, Class, Section, Roll,
+// FatherName, MotherName, GuardianName, RelationOfGuardian and
+// CurrentAddress, plus Date, Purpose, ReportID, GeneratedBy and OnBehalfOf.
+// Optional fields that are not set are left out, so a template referring to
+// one fails instead of rendering a blank.
+func (rs *ReportService) renderCoverLetter(student *models.Student, metadata *models.ReportMetadata, options *config.CoverLetterOptions) (string, error) {
+	if rs.config.Report.CoverLetterTemplate == "" {
+		return "", fmt.Errorf("cover letter enabled but no cover letter template is configured")
+	}
+
+	tmpl, err := config.ParseCoverLetterTemplate(rs.config.Report.CoverLetterTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	vars := map[string]interface{}{
+		"StudentID":   student.ID,
+		"Name":        student.Name,
+		"Email":       student.Email,
+		"Date":        metadata.GeneratedAt.Format("January 2, 2006"),
+		"Purpose":     options.Purpose,
+		"ReportID":    metadata.ReportID,
+		"GeneratedBy": metadata.GeneratedBy,
+	}
+
+	optional := map[string]*string{
+		"Class":              student.Class,
+		"Section":            student.Section,
+		"FatherName":         student.FatherName,
+		"MotherName":         student.MotherName,
+		"GuardianName":       student.GuardianName,
+		"RelationOfGuardian": student.RelationOfGuardian,
+		"CurrentAddress":     student.CurrentAddress,
+	}
+	for key, value := range optional {
+		if value != nil {
+			vars[key] = *value
+		}
+	}
+	if student.Roll != nil {
+		vars["Roll"] = *student.Roll
+	}
+	if metadata.OnBehalfOf != "" {
+		vars["OnBehalfOf"] = metadata.OnBehalfOf
+	}
+
+	var letter strings.Builder
+	if err := tmpl.Execute(&letter, vars); err != nil {
+		return "", fmt.Errorf("failed to render cover letter for student %d: %w", student.ID, err)
+	}
+
+	return letter.String(), nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportService_CoverLetter(t *testing.T) {
+	className := "Grade 10"
+	mockStudent := &models.Student{ID: 1, Name: "John Doe", Class: &className}
+
+	tests := []struct {
+		name           string
+		template       string
+		expectedLetter string
+		expectedError  string
+	}{
+		{
+			name:           "Renders student fields, date and purpose",
+			template:       "Dear parent of {{.Name}} ({{.Class}}),\nDate: {{.Date}}\nRe: {{.Purpose}}",
+			expectedLetter: "Dear parent of John Doe (Grade 10),\nDate: " + time.Now().Format("January 2, 2006") + "\nRe: Scholarship application",
+		},
+		{
+			name:          "Unset student field",
+			template:      "Dear {{.GuardianName}},",
+			expectedError: `map has no entry for key "GuardianName"`,
+		},
+		{
+			name:          "Unknown variable",
+			template:      "Dear {{.Nickname}},",
+			expectedError: `map has no entry for key "Nickname"`,
+		},
+		{
+			name:          "No template configured",
+			template:      "",
+			expectedError: "no cover letter template is configured",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNodeClient := new(MockNodeJSClient)
+			mockPDFGen := new(MockPDFGenerator)
+
+			var rendered *models.ReportMetadata
+			mockNodeClient.On("GetStudentByID", 1).Return(mockStudent, nil)
+			mockPDFGen.On("GenerateStudentReport", mockStudent, mock.AnythingOfType("*models.ReportMetadata")).
+				Run(func(args mock.Arguments) { rendered = args.Get(1).(*models.ReportMetadata) }).
+				Return("/path/to/report.pdf", nil).Maybe()
+
+			cfg := &config.Config{Report: config.ReportConfig{
+				CoverLetterTemplate: tt.template,
+				Profiles: map[string]config.ReportProfile{
+					"packet": {Format: "pdf", CoverLetter: &config.CoverLetterOptions{Purpose: "Scholarship application"}},
+				},
+			}}
+			service := NewReportService(mockNodeClient, mockPDFGen, cfg)
+
+			_, err := service.GenerateStudentReportWithProfile(context.Background(), 1, ReportOptions{GeneratedBy: "Test User", Profile: "packet"})
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				mockPDFGen.AssertNotCalled(t, "GenerateStudentReport", mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLetter, rendered.CoverLetter)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"student-report-service/internal/models"
+)
+
+// upstreamDateLayout is a calendar date. Dates have no time of day to
+// convert, so they are shown in this layout, without a zone.
+const upstreamDateLayout = "2006-01-02"
+
+// offsetTimestampLayouts are upstream timestamp formats that carry a UTC offset
+var offsetTimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999 -0700",
+}
+
+// naiveTimestampLayouts are upstream timestamp formats without a UTC offset
+var naiveTimestampLayouts = []string{
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+}
+
+// localizeDates prepares the student's upstream calendar dates for display.
+// Each keeps its day in the upstream zone and is shown without a time.
+// Values that aren't a recognised date are left as received and returned
+// as data-quality warnings.
+func localizeDates(student *models.Student, upstream *time.Location) []string {
+	fields := []struct {
+		label string
+		value **string
+	}{
+		{"date of birth", &student.DOB},
+		{"admission date", &student.AdmissionDate},
+	}
+
+	var warnings []string
+	for _, field := range fields {
+		if *field.value == nil || strings.TrimSpace(**field.value) == "" {
+			continue
+		}
+
+		converted, err := localizeDate(**field.value, upstream)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("data quality: student %d %s %v; shown as received", student.ID, field.label, err))
+			continue
+		}
+		*field.value = &converted
+	}
+	return warnings
+}
+
+// localizeDate returns the calendar date of an upstream date field. Date
+// columns are often serialized as midnight in the upstream zone written in
+// UTC, e.g. 2010-05-14T00:00:00.000Z or 2010-05-13T18:30:00.000Z, so values
+// with a UTC offset are read in the upstream zone. Converting them to the
+// display zone would move the date to another day.
+func localizeDate(value string, upstream *time.Location) (string, error) {
+	value = strings.TrimSpace(value)
+
+	if _, err := time.Parse(upstreamDateLayout, value); err == nil {
+		return value, nil
+	}
+
+	for _, layout := range offsetTimestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.In(upstream).Format(upstreamDateLayout), nil
+		}
+	}
+
+	// A timestamp without an offset is already the upstream wall clock
+	for _, layout := range naiveTimestampLayouts {
+		if wall, err := time.Parse(layout, value); err == nil {
+			return wall.Format(upstreamDateLayout), nil
+		}
+	}
+
+	return "", fmt.Errorf("is not a recognised date")
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalizeDate(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		value         string
+		upstream      *time.Location
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "Date is shown as received",
+			value:    "2010-05-14",
+			upstream: time.UTC,
+			expected: "2010-05-14",
+		},
+		{
+			name:     "Midnight UTC keeps its day",
+			value:    "2010-05-14T00:00:00.000Z",
+			upstream: time.UTC,
+			expected: "2010-05-14",
+		},
+		{
+			name:     "Midnight in the upstream zone written in UTC",
+			value:    "2010-05-13T18:30:00.000Z",
+			upstream: kolkata,
+			expected: "2010-05-14",
+		},
+		{
+			name:     "Naive timestamp keeps its day",
+			value:    "2010-05-14 23:30:00",
+			upstream: kolkata,
+			expected: "2010-05-14",
+		},
+		{
+			name:          "Unparseable value",
+			value:         "15th of May",
+			upstream:      time.UTC,
+			expectedError: "is not a recognised date",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := localizeDate(tt.value, tt.upstream)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestLocalizeDates(t *testing.T) {
+	dob := "2010-05-01"
+	admission := "not recorded yet"
+	student := &models.Student{ID: 7, DOB: &dob, AdmissionDate: &admission}
+
+	warnings := localizeDates(student, time.UTC)
+
+	assert.Equal(t, []string{"data quality: student 7 admission date is not a recognised date; shown as received"}, warnings)
+	assert.Equal(t, "2010-05-01", *student.DOB)
+	assert.Equal(t, "not recorded yet", *student.AdmissionDate)
+
+	// Timestamps keep their day in the upstream zone
+	dob = "2010-05-14T00:00:00.000Z"
+	admission = "2024-01-15T18:30:00.000Z"
+	student.DOB, student.AdmissionDate = &dob, &admission
+	assert.Empty(t, localizeDates(student, time.UTC))
+	assert.Equal(t, "2010-05-14", *student.DOB)
+	assert.Equal(t, "2024-01-15", *student.AdmissionDate)
+	assert.Equal(t, "2024-01-15T18:30:00.000Z", admission, "the fetched value is not modified")
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HealthCacheHeaders returns the Cache-Control and Expires headers for a
+// health result that was produced age ago and is cached for ttl. Proxies may
+// keep the response only for the rest of its TTL. A ttl of zero means health
+// is not cached, and the headers forbid caching; so does a result that has
+// already outlived its TTL.
+func HealthCacheHeaders(age, ttl time.Duration, now time.Time) http.Header {
+	header := make(http.Header)
+
+	remaining := ttl - age
+	if ttl <= 0 || remaining < time.Second {
+		header.Set("Cache-Control", "no-cache, no-store, must-revalidate")
+		header.Set("Expires", now.UTC().Format(http.TimeFormat))
+		return header
+	}
+
+	// Round down so that caches never keep a result past its TTL
+	seconds := int64(remaining / time.Second)
+	header.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", seconds))
+	header.Set("Expires", now.Add(time.Duration(seconds)*time.Second).UTC().Format(http.TimeFormat))
+	return header
+}
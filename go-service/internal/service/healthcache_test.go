@@ -0,0 +1,66 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthCacheHeaders(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		age                  time.Duration
+		ttl                  time.Duration
+		expectedCacheControl string
+		expectedExpires      time.Time
+	}{
+		{
+			name:                 "Caching disabled",
+			age:                  0,
+			ttl:                  0,
+			expectedCacheControl: "no-cache, no-store, must-revalidate",
+			expectedExpires:      now,
+		},
+		{
+			name:                 "Fresh result",
+			age:                  0,
+			ttl:                  30 * time.Second,
+			expectedCacheControl: "public, max-age=30",
+			expectedExpires:      now.Add(30 * time.Second),
+		},
+		{
+			name:                 "Remaining TTL rounds down",
+			age:                  12500 * time.Millisecond,
+			ttl:                  30 * time.Second,
+			expectedCacheControl: "public, max-age=17",
+			expectedExpires:      now.Add(17 * time.Second),
+		},
+		{
+			name:                 "Expired result",
+			age:                  45 * time.Second,
+			ttl:                  30 * time.Second,
+			expectedCacheControl: "no-cache, no-store, must-revalidate",
+			expectedExpires:      now,
+		},
+		{
+			name:                 "Less than a second left",
+			age:                  29500 * time.Millisecond,
+			ttl:                  30 * time.Second,
+			expectedCacheControl: "no-cache, no-store, must-revalidate",
+			expectedExpires:      now,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := HealthCacheHeaders(tt.age, tt.ttl, now)
+
+			assert.Equal(t, tt.expectedCacheControl, header.Get("Cache-Control"))
+			assert.Equal(t, tt.expectedExpires.Format(http.TimeFormat), header.Get("Expires"))
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+)
+
+// HouseholdReportResult represents the result of a household report generation
+type HouseholdReportResult struct {
+	ReportID    string                    `json:"report_id"`
+	StudentIDs  []int                     `json:"student_ids"`
+	Failures    []models.HouseholdFailure `json:"failures,omitempty"`
+	FilePath    string                    `json:"file_path"`
+	GeneratedAt time.Time                 `json:"generated_at"`
+	GeneratedBy string                    `json:"generated_by"`
+	OnBehalfOf  string                    `json:"on_behalf_of,omitempty"`
+	FileSize    int64                     `json:"file_size"`
+	Warnings    []string                  `json:"warnings,omitempty"`
+}
+
+// GenerateHouseholdReport generates a single PDF covering several siblings.
+// Students that can't be fetched are listed on the cover page and in the
+// result's Failures instead of failing the whole report. Household reports
+// always use the default profile, so opts.Profile must be empty or "default".
+func (rs *ReportService) GenerateHouseholdReport(studentIDs []int, opts ReportOptions) (*HouseholdReportResult, error) {
+	if rs.config.Report.ReadOnly {
+		return nil, ErrReadOnly
+	}
+
+	if rs.config.SafeMode {
+		return nil, ErrDisabledInSafeMode
+	}
+
+	if len(studentIDs) == 0 {
+		return nil, fmt.Errorf("invalid household: no student IDs provided")
+	}
+
+	if opts.Profile != "" && opts.Profile != config.DefaultProfileName {
+		return nil, fmt.Errorf("invalid household: report profile %q is not supported, household reports use the default profile", opts.Profile)
+	}
+
+	profile, err := rs.resolveProfile(opts)
+	if err != nil {
+		return nil, err
+	}
+	generatedBy, onBehalfOf := opts.GeneratedBy, opts.OnBehalfOf
+
+	display, err := rs.config.Report.DisplayLocation()
+	if err != nil {
+		return nil, err
+	}
+
+	generatedAt := time.Now().In(display)
+	reportID := fmt.Sprintf("RPT-HH-%d-%d", studentIDs[0], generatedAt.Unix())
+
+	var (
+		members  []models.HouseholdMember
+		failures []models.HouseholdFailure
+		warnings []string
+		included []int
+	)
+	seen := make(map[int]bool)
+
+	for _, studentID := range studentIDs {
+		if seen[studentID] {
+			continue
+		}
+		seen[studentID] = true
+
+		member, memberWarnings, err := rs.householdMember(studentID, generatedBy, onBehalfOf, profile, reportID)
+		if err != nil {
+			failures = append(failures, models.HouseholdFailure{StudentID: studentID, Reason: err.Error()})
+			continue
+		}
+
+		members = append(members, *member)
+		included = append(included, studentID)
+		warnings = append(warnings, memberWarnings...)
+	}
+
+	if len(members) == 0 {
+		return nil, fmt.Errorf("failed to fetch any student in the household: %s", failures[0].Reason)
+	}
+
+	metadata := &models.ReportMetadata{
+		GeneratedAt: generatedAt,
+		GeneratedBy: generatedBy,
+		OnBehalfOf:  onBehalfOf,
+		ReportID:    reportID,
+		Watermark:   profile.Watermark,
+		Unsigned:    !rs.config.Report.SignsReports(profile),
+	}
+
+	filePath, err := rs.pdfGenerator.GenerateHouseholdReport(members, failures, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF report: %w", err)
+	}
+	if metadata.Truncated {
+		warnings = append(warnings, rs.truncationWarning())
+	}
+
+	return &HouseholdReportResult{
+		ReportID:    metadata.ReportID,
+		StudentIDs:  included,
+		Failures:    failures,
+		FilePath:    filePath,
+		GeneratedAt: generatedAt,
+		GeneratedBy: generatedBy,
+		OnBehalfOf:  onBehalfOf,
+		FileSize:    rs.getActualFileSize(filePath),
+		Warnings:    warnings,
+	}, nil
+}
+
+// householdMember fetches a student and prepares their section of a household report
+func (rs *ReportService) householdMember(studentID int, generatedBy, onBehalfOf string, profile config.ReportProfile, reportID string) (*models.HouseholdMember, []string, error) {
+	if studentID <= 0 {
+		return nil, nil, fmt.Errorf("invalid student ID: %d", studentID)
+	}
+
+	student, err := rs.nodeClient.GetStudentByID(studentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch student data: %w", err)
+	}
+	if student == nil {
+		return nil, nil, fmt.Errorf("%w: ID %d", ErrStudentNotFound, studentID)
+	}
+
+	metadata, warnings, err := rs.prepareMetadata(student, generatedBy, onBehalfOf, profile, reportID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &models.HouseholdMember{Student: student, Metadata: metadata}, warnings, nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportService_GenerateHouseholdReport(t *testing.T) {
+	john := &models.Student{ID: 1, Name: "John Doe"}
+	jane := &models.Student{ID: 2, Name: "Jane Doe"}
+
+	t.Run("Continues past individual failures", func(t *testing.T) {
+		mockNodeClient := new(MockNodeJSClient)
+		mockPDFGen := new(MockPDFGenerator)
+
+		mockNodeClient.On("GetStudentByID", 1).Return(john, nil)
+		mockNodeClient.On("GetStudentByID", 2).Return(jane, nil)
+		mockNodeClient.On("GetStudentByID", 3).Return(nil, errors.New("API Error 404: Student not found"))
+		mockPDFGen.On("GenerateHouseholdReport",
+			mock.MatchedBy(func(members []models.HouseholdMember) bool {
+				return len(members) == 2 && members[0].Student == john && members[1].Student == jane &&
+					members[0].Metadata.ReportID == members[1].Metadata.ReportID
+			}),
+			mock.MatchedBy(func(failures []models.HouseholdFailure) bool {
+				return len(failures) == 1 && failures[0].StudentID == 3
+			}),
+			mock.AnythingOfType("*models.ReportMetadata"),
+		).Return("/path/to/household.pdf", nil)
+
+		service := NewReportService(mockNodeClient, mockPDFGen, &config.Config{})
+		result, err := service.GenerateHouseholdReport([]int{1, 2, 3, 1}, ReportOptions{GeneratedBy: "Parent Portal"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, result.StudentIDs)
+		require.Len(t, result.Failures, 1)
+		assert.Contains(t, result.Failures[0].Reason, "Student not found")
+		assert.Equal(t, "/path/to/household.pdf", result.FilePath)
+		mockNodeClient.AssertNumberOfCalls(t, "GetStudentByID", 3)
+		mockPDFGen.AssertExpectations(t)
+	})
+
+	t.Run("All students fail", func(t *testing.T) {
+		mockNodeClient := new(MockNodeJSClient)
+		mockPDFGen := new(MockPDFGenerator)
+
+		mockNodeClient.On("GetStudentByID", 3).Return(nil, errors.New("API Error 404: Student not found"))
+
+		service := NewReportService(mockNodeClient, mockPDFGen, &config.Config{})
+		result, err := service.GenerateHouseholdReport([]int{3}, ReportOptions{GeneratedBy: "Parent Portal"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		mockPDFGen.AssertNotCalled(t, "GenerateHouseholdReport", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("No students", func(t *testing.T) {
+		service := NewReportService(new(MockNodeJSClient), new(MockPDFGenerator), &config.Config{})
+		_, err := service.GenerateHouseholdReport(nil, ReportOptions{GeneratedBy: "Parent Portal"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Profiles other than default", func(t *testing.T) {
+		mockNodeClient := new(MockNodeJSClient)
+		service := NewReportService(mockNodeClient, new(MockPDFGenerator), &config.Config{})
+		_, err := service.GenerateHouseholdReport([]int{1}, ReportOptions{GeneratedBy: "Parent Portal", Profile: "draft"})
+		assert.ErrorContains(t, err, "household reports use the default profile")
+		mockNodeClient.AssertNotCalled(t, "GetStudentByID", mock.Anything)
+	})
+}
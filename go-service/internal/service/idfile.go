@@ -0,0 +1,160 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// idColumnNames are the header names recognised as the student ID column
+var idColumnNames = map[string]bool{
+	"student_id": true,
+	"studentid":  true,
+	"id":         true,
+}
+
+// IDFileLineError describes a line of an ID file that could not be used
+type IDFileLineError struct {
+	Line   int    `json:"line"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+// BatchFailure records a student whose report could not be generated
+type BatchFailure struct {
+	StudentID int    `json:"student_id"`
+	Reason    string `json:"reason"`
+}
+
+// IDFileResult summarises a batch run driven by an ID file
+type IDFileResult struct {
+	File       string            `json:"file"`
+	StudentIDs []int             `json:"student_ids"`
+	Duplicates int               `json:"duplicates"`
+	Invalid    []IDFileLineError `json:"invalid,omitempty"`
+	Reports    []*ReportResult   `json:"reports"`
+	Failures   []BatchFailure    `json:"failures,omitempty"`
+}
+
+// GenerateFromIDFile generates a report for every student listed in path.
+// The file is either a plain list with one ID per line or a CSV whose header
+// names a student_id (or id) column. Duplicate IDs are generated once, and
+// malformed lines and failed reports are listed in the result rather than
+// aborting the run.
+func (rs *ReportService) GenerateFromIDFile(path string, generatedBy string) (*IDFileResult, error) {
+	if rs.config.Report.ReadOnly {
+		return nil, ErrReadOnly
+	}
+
+	if rs.config.SafeMode {
+		return nil, ErrDisabledInSafeMode
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ID file: %w", err)
+	}
+	defer file.Close()
+
+	result, err := readIDFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ID file %s: %w", path, err)
+	}
+	result.File = path
+
+	if len(result.StudentIDs) == 0 && len(result.Invalid) == 0 {
+		return nil, fmt.Errorf("ID file %s contains no student IDs", path)
+	}
+
+	result.Reports = []*ReportResult{}
+	for _, studentID := range result.StudentIDs {
+		report, err := rs.GenerateStudentReport(studentID, generatedBy)
+		if err != nil {
+			result.Failures = append(result.Failures, BatchFailure{StudentID: studentID, Reason: err.Error()})
+			continue
+		}
+		result.Reports = append(result.Reports, report)
+	}
+
+	return result, nil
+}
+
+// readIDFile parses student IDs from r, keeping the first occurrence of each
+func readIDFile(r io.Reader) (*IDFileResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	result := &IDFileResult{StudentIDs: []int{}}
+	seen := make(map[int]bool)
+	column := 0
+	first := true
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		if first {
+			first = false
+			// Spreadsheet exports often start with a byte order mark
+			record[0] = strings.TrimPrefix(record[0], "\ufeff")
+			if _, err := strconv.Atoi(strings.TrimSpace(record[0])); err != nil {
+				if index, ok := idColumn(record); ok {
+					column = index
+					continue
+				}
+				if len(record) > 1 {
+					return nil, fmt.Errorf("header has no student_id column")
+				}
+			}
+		}
+
+		if column >= len(record) {
+			result.Invalid = append(result.Invalid, IDFileLineError{
+				Line:   line,
+				Reason: "missing student ID column",
+			})
+			continue
+		}
+
+		value := strings.TrimSpace(record[column])
+		studentID, err := strconv.Atoi(value)
+		if err != nil || studentID <= 0 {
+			result.Invalid = append(result.Invalid, IDFileLineError{
+				Line:   line,
+				Value:  value,
+				Reason: "invalid student ID",
+			})
+			continue
+		}
+
+		if seen[studentID] {
+			result.Duplicates++
+			continue
+		}
+		seen[studentID] = true
+		result.StudentIDs = append(result.StudentIDs, studentID)
+	}
+
+	return result, nil
+}
+
+// idColumn returns the index of the student ID column in a header record
+func idColumn(header []string) (int, bool) {
+	for i, name := range header {
+		if idColumnNames[strings.ToLower(strings.TrimSpace(name))] {
+			return i, true
+		}
+	}
+	return 0, false
+}
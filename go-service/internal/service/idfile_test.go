@@ -0,0 +1,142 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func writeIDFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestReadIDFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expectedIDs   []int
+		duplicates    int
+		invalid       []IDFileLineError
+		expectedError string
+	}{
+		{
+			name:        "Plain list",
+			content:     "3\n1\n\n2\n",
+			expectedIDs: []int{3, 1, 2},
+		},
+		{
+			name:        "Duplicates are generated once",
+			content:     "1\n2\n1\n 2\n",
+			expectedIDs: []int{1, 2},
+			duplicates:  2,
+		},
+		{
+			name:        "Malformed lines are reported with line numbers",
+			content:     "1\nabc\n-4\n2\n",
+			expectedIDs: []int{1, 2},
+			invalid: []IDFileLineError{
+				{Line: 2, Value: "abc", Reason: "invalid student ID"},
+				{Line: 3, Value: "-4", Reason: "invalid student ID"},
+			},
+		},
+		{
+			name:        "CSV with a named column",
+			content:     "\ufeffname,Student_ID,class\nJohn,1,10\nJane,2,9\nJim,,9\nJoe\n",
+			expectedIDs: []int{1, 2},
+			invalid: []IDFileLineError{
+				{Line: 4, Value: "", Reason: "invalid student ID"},
+				{Line: 5, Reason: "missing student ID column"},
+			},
+		},
+		{
+			name:          "CSV header without an ID column",
+			content:       "name,class\nJohn,10\n",
+			expectedError: "header has no student_id column",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeIDFile(t, "ids.csv", tt.content)
+			file, err := os.Open(path)
+			require.NoError(t, err)
+			defer file.Close()
+
+			result, err := readIDFile(file)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedIDs, result.StudentIDs)
+			assert.Equal(t, tt.duplicates, result.Duplicates)
+			assert.Equal(t, tt.invalid, result.Invalid)
+		})
+	}
+}
+
+func TestReportService_GenerateFromIDFile(t *testing.T) {
+	t.Run("Generates each listed student", func(t *testing.T) {
+		mockNodeClient := new(MockNodeJSClient)
+		mockPDFGen := new(MockPDFGenerator)
+
+		mockNodeClient.On("GetStudentByID", 1).Return(&models.Student{ID: 1, Name: "John Doe"}, nil)
+		mockNodeClient.On("GetStudentByID", 2).Return(nil, errors.New("API Error 404: Student not found"))
+		mockPDFGen.On("GenerateStudentReport", mock.AnythingOfType("*models.Student"), mock.AnythingOfType("*models.ReportMetadata")).Return("/path/to/report.pdf", nil)
+
+		path := writeIDFile(t, "ids.txt", "1\n2\nx\n1\n")
+		service := NewReportService(mockNodeClient, mockPDFGen, &config.Config{})
+		result, err := service.GenerateFromIDFile(path, "Operator")
+
+		require.NoError(t, err)
+		assert.Equal(t, path, result.File)
+		assert.Equal(t, []int{1, 2}, result.StudentIDs)
+		assert.Equal(t, 1, result.Duplicates)
+		assert.Equal(t, []IDFileLineError{{Line: 3, Value: "x", Reason: "invalid student ID"}}, result.Invalid)
+		require.Len(t, result.Reports, 1)
+		assert.Equal(t, 1, result.Reports[0].StudentID)
+		require.Len(t, result.Failures, 1)
+		assert.Equal(t, 2, result.Failures[0].StudentID)
+		mockNodeClient.AssertExpectations(t)
+		mockPDFGen.AssertNumberOfCalls(t, "GenerateStudentReport", 1)
+	})
+
+	t.Run("Empty file", func(t *testing.T) {
+		path := writeIDFile(t, "ids.txt", "\n\n")
+		service := NewReportService(new(MockNodeJSClient), new(MockPDFGenerator), &config.Config{})
+
+		_, err := service.GenerateFromIDFile(path, "Operator")
+
+		assert.ErrorContains(t, err, "contains no student IDs")
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		service := NewReportService(new(MockNodeJSClient), new(MockPDFGenerator), &config.Config{})
+
+		_, err := service.GenerateFromIDFile(filepath.Join(t.TempDir(), "missing.csv"), "Operator")
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("Read-only mode", func(t *testing.T) {
+		path := writeIDFile(t, "ids.txt", "1\n")
+		cfg := &config.Config{Report: config.ReportConfig{ReadOnly: true}}
+		service := NewReportService(new(MockNodeJSClient), new(MockPDFGenerator), cfg)
+
+		_, err := service.GenerateFromIDFile(path, "Operator")
+
+		assert.ErrorIs(t, err, ErrReadOnly)
+	})
+}
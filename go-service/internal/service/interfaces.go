@@ -1,11 +1,17 @@
 package service
 
-import "student-report-service/internal/models"
+import (
+	"context"
+
+	"student-report-service/internal/models"
+	"student-report-service/internal/pdf"
+)
 
 // NodeJSClientInterface defines the interface for Node.js API client
 type NodeJSClientInterface interface {
 	GetStudentByID(studentID int) (*models.Student, error)
 	GetAllStudents(filters map[string]string) ([]models.StudentListItem, error)
+	StreamAllStudents(ctx context.Context, filters map[string]string, fn func(models.StudentListItem) error) error
 	HealthCheck() error
 	Close() error
 }
@@ -13,5 +19,29 @@ type NodeJSClientInterface interface {
 // PDFGeneratorInterface defines the interface for PDF generation
 type PDFGeneratorInterface interface {
 	GenerateStudentReport(student *models.Student, metadata *models.ReportMetadata) (string, error)
-	CleanupOldReports() error
+	GenerateStudentTextReport(student *models.Student, metadata *models.ReportMetadata) (string, error)
+	GenerateHouseholdReport(members []models.HouseholdMember, failures []models.HouseholdFailure, metadata *models.ReportMetadata) (string, error)
+	FindReport(reportID string) (string, error)
+	VerifyWithRecord(reportPath, recordPath string) error
+	RebuildIndex() (int, error)
+	CleanupOldReports(ctx context.Context) (*pdf.CleanupSummary, error)
+}
+
+// PhotoClientInterface defines the interface for the student photo service client
+type PhotoClientInterface interface {
+	GetStudentPhoto(studentID int) (*models.StudentPhoto, error)
+}
+
+// Enricher augments a fetched student and its report metadata with data from
+// other systems before the report is rendered
+type Enricher interface {
+	Enrich(student *models.Student, metadata *models.ReportMetadata) error
+}
+
+// EnricherFunc adapts a function to the Enricher interface
+type EnricherFunc func(student *models.Student, metadata *models.ReportMetadata) error
+
+// Enrich calls f(student, metadata)
+func (f EnricherFunc) Enrich(student *models.Student, metadata *models.ReportMetadata) error {
+	return f(student, metadata)
 }
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/sirupsen/logrus"
+)
+
+// PregenerationGeneratedBy is recorded as the author of pre-generated reports
+const PregenerationGeneratedBy = "Pre-generation"
+
+// pregeneratedReport is a warm-list report together with the data it was rendered from
+type pregeneratedReport struct {
+	result      *ReportResult
+	fingerprint string
+}
+
+// pregeneratedReport returns a copy of the pre-generated report for a
+// student, or nil if there is none, it was rendered from other data than
+// student's or its file has since been removed. Like the file, the copy names
+// PregenerationGeneratedBy as its author.
+func (rs *ReportService) pregeneratedReport(student *models.Student) *ReportResult {
+	rs.pregeneratedMutex.RLock()
+	entry, ok := rs.pregenerated[student.ID]
+	rs.pregeneratedMutex.RUnlock()
+
+	if !ok {
+		return nil
+	}
+
+	// Data changed since the last refresh is rendered afresh rather than
+	// served stale. The next refresh replaces the entry.
+	fingerprint, err := studentFingerprint(student)
+	if err != nil || fingerprint != entry.fingerprint {
+		return nil
+	}
+
+	if _, err := os.Stat(entry.result.FilePath); err != nil {
+		rs.pregeneratedMutex.Lock()
+		if rs.pregenerated[student.ID] == entry {
+			delete(rs.pregenerated, student.ID)
+		}
+		rs.pregeneratedMutex.Unlock()
+		return nil
+	}
+
+	result := *entry.result
+	result.Warnings = append([]string(nil), entry.result.Warnings...)
+	return &result
+}
+
+// RefreshPregeneratedReports generates reports for the configured warm list.
+// A student's report is only re-rendered when their data has changed since
+// the last run or the cached file has disappeared.
+func (rs *ReportService) RefreshPregeneratedReports() error {
+	if rs.config.Report.ReadOnly {
+		return ErrReadOnly
+	}
+
+	if rs.config.SafeMode {
+		return ErrDisabledInSafeMode
+	}
+
+	studentIDs, err := rs.config.Report.WarmStudents()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, studentID := range studentIDs {
+		if err := rs.refreshPregeneratedReport(studentID); err != nil {
+			errs = append(errs, fmt.Errorf("student %d: %w", studentID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (rs *ReportService) refreshPregeneratedReport(studentID int) error {
+	if studentID <= 0 {
+		return fmt.Errorf("invalid student ID: %d", studentID)
+	}
+
+	student, err := rs.nodeClient.GetStudentByID(studentID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch student data: %w", err)
+	}
+	if student == nil {
+		return fmt.Errorf("%w: ID %d", ErrStudentNotFound, studentID)
+	}
+
+	fingerprint, err := studentFingerprint(student)
+	if err != nil {
+		return err
+	}
+
+	rs.pregeneratedMutex.RLock()
+	entry, ok := rs.pregenerated[studentID]
+	rs.pregeneratedMutex.RUnlock()
+
+	if ok && entry.fingerprint == fingerprint {
+		if _, err := os.Stat(entry.result.FilePath); err == nil {
+			return nil
+		}
+	}
+
+	profile, err := rs.config.Report.Profile(config.DefaultProfileName)
+	if err != nil {
+		return err
+	}
+	profile = rs.applyWatermarkCondition(profile, config.ReportContext{})
+
+	result, err := rs.renderReport(student, PregenerationGeneratedBy, "", profile)
+	if err != nil {
+		return err
+	}
+
+	rs.pregeneratedMutex.Lock()
+	if rs.pregenerated == nil {
+		rs.pregenerated = make(map[int]*pregeneratedReport)
+	}
+	rs.pregenerated[studentID] = &pregeneratedReport{result: result, fingerprint: fingerprint}
+	rs.pregeneratedMutex.Unlock()
+
+	return nil
+}
+
+// StartPregeneration refreshes the warm list immediately and then on every
+// WarmRefreshInterval until ctx is cancelled. It returns once the loop is
+// running, and does nothing in read-only mode or safe mode.
+func (rs *ReportService) StartPregeneration(ctx context.Context, logger *logrus.Logger) {
+	if rs.config.Report.ReadOnly || rs.config.SafeMode {
+		return
+	}
+
+	studentIDs, err := rs.config.Report.WarmStudents()
+	if err != nil {
+		logger.WithError(err).Warn("Pre-generation disabled")
+		return
+	}
+	if len(studentIDs) == 0 {
+		return
+	}
+
+	interval := rs.config.Report.WarmRefreshInterval
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+
+	refresh := func() {
+		if err := rs.RefreshPregeneratedReports(); err != nil {
+			logger.WithError(err).Warn("Failed to pre-generate some reports")
+			return
+		}
+		logger.WithField("students", len(studentIDs)).Debug("Pre-generated reports refreshed")
+	}
+
+	go func() {
+		refresh()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				refresh()
+			}
+		}
+	}()
+}
+
+// studentFingerprint hashes the student data a report is rendered from
+func studentFingerprint(student *models.Student) (string, error) {
+	data, err := json.Marshal(student)
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint student data: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportService_Pregeneration(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.pdf")
+	require.NoError(t, os.WriteFile(reportPath, []byte("%PDF-1.3"), 0644))
+
+	student := &models.Student{ID: 1, Name: "John Doe"}
+	cfg := &config.Config{Report: config.ReportConfig{WarmStudentIDs: "1"}}
+
+	mockNodeClient := new(MockNodeJSClient)
+	mockPDFGen := new(MockPDFGenerator)
+	mockNodeClient.On("GetStudentByID", 1).Return(student, nil)
+	mockPDFGen.On("GenerateStudentReport", mock.AnythingOfType("*models.Student"), mock.AnythingOfType("*models.ReportMetadata")).Return(reportPath, nil)
+
+	service := NewReportService(mockNodeClient, mockPDFGen, cfg)
+
+	// First refresh renders the warm list
+	require.NoError(t, service.RefreshPregeneratedReports())
+	mockPDFGen.AssertNumberOfCalls(t, "GenerateStudentReport", 1)
+
+	// Unchanged data is not re-rendered
+	require.NoError(t, service.RefreshPregeneratedReports())
+	mockPDFGen.AssertNumberOfCalls(t, "GenerateStudentReport", 1)
+
+	// Requests fetch the student but are served from the cache without rendering
+	result, err := service.GenerateStudentReport(1, "Test User")
+	require.NoError(t, err)
+	assert.Equal(t, reportPath, result.FilePath)
+	assert.Equal(t, PregenerationGeneratedBy, result.GeneratedBy)
+	assert.Zero(t, result.Attempts)
+	mockNodeClient.AssertNumberOfCalls(t, "GetStudentByID", 3)
+	mockPDFGen.AssertNumberOfCalls(t, "GenerateStudentReport", 1)
+
+	// Each request gets its own copy
+	result.Warnings = append(result.Warnings, "changed by caller")
+	other, err := service.GenerateStudentReport(1, "Other User")
+	require.NoError(t, err)
+	assert.Equal(t, PregenerationGeneratedBy, other.GeneratedBy)
+	assert.Empty(t, other.Warnings)
+
+	// Changed data is rendered instead of served stale
+	student.Email = "john@example.com"
+	result, err = service.GenerateStudentReport(1, "Test User")
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Attempts)
+	mockPDFGen.AssertNumberOfCalls(t, "GenerateStudentReport", 2)
+
+	// and re-rendered on the next refresh
+	require.NoError(t, service.RefreshPregeneratedReports())
+	mockPDFGen.AssertNumberOfCalls(t, "GenerateStudentReport", 3)
+
+	// A removed file falls back to normal generation
+	require.NoError(t, os.Remove(reportPath))
+	_, err = service.GenerateStudentReport(1, "Test User")
+	require.NoError(t, err)
+	mockPDFGen.AssertNumberOfCalls(t, "GenerateStudentReport", 4)
+
+	// Reports on behalf of someone are rendered rather than served from the cache
+	require.NoError(t, service.RefreshPregeneratedReports())
+	mockPDFGen.AssertNumberOfCalls(t, "GenerateStudentReport", 5)
+	result, err = service.GenerateStudentReportWithProfile(context.Background(), 1, ReportOptions{GeneratedBy: "Admin", OnBehalfOf: "Ms. Smith"})
+	require.NoError(t, err)
+	assert.Equal(t, "Ms. Smith", result.OnBehalfOf)
+	mockPDFGen.AssertNumberOfCalls(t, "GenerateStudentReport", 6)
+
+	// So are reports with a status
+	_, err = service.GenerateStudentReportWithProfile(context.Background(), 1, ReportOptions{GeneratedBy: "Admin", ReportContext: config.ReportContext{Status: "draft"}})
+	require.NoError(t, err)
+	mockPDFGen.AssertNumberOfCalls(t, "GenerateStudentReport", 7)
+}
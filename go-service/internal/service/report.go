@@ -1,21 +1,49 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"student-report-service/internal/client"
 	"student-report-service/internal/config"
+	"student-report-service/internal/export"
 	"student-report-service/internal/models"
 	"student-report-service/internal/pdf"
 )
 
+// ErrReadOnly is returned by methods that create or remove reports when the
+// service runs in read-only mode
+var ErrReadOnly = errors.New("report service is read-only")
+
+// ErrDisabledInSafeMode is returned by methods that need an external
+// integration, such as the Node.js API, when the service runs in safe mode
+var ErrDisabledInSafeMode = errors.New("operation is disabled in safe mode")
+
+// ErrGeneratedByNotAllowed is returned when a caller gives a reserved name as generatedBy
+var ErrGeneratedByNotAllowed = errors.New("generated_by is a reserved name")
+
+// ErrFilterNotAllowed is returned when a student list filter key is not
+// permitted by the filter policy
+var ErrFilterNotAllowed = errors.New("student filter not allowed")
+
 // ReportService orchestrates the student report generation process
 type ReportService struct {
 	nodeClient   NodeJSClientInterface
 	pdfGenerator PDFGeneratorInterface
+	photoClient  PhotoClientInterface
+	enrichers    []Enricher
 	config       *config.Config
+
+	// Pre-generated reports for the warm list, keyed by student ID
+	pregenerated      map[int]*pregeneratedReport
+	pregeneratedMutex sync.RWMutex
 }
 
 // NewReportService creates a new report service
@@ -36,8 +64,26 @@ func NewReportServiceWithConcreteTypes(nodeClient *client.NodeJSClient, pdfGener
 	}
 }
 
+// SetPhotoClient enables embedding student photos in generated reports
+func (rs *ReportService) SetPhotoClient(photoClient PhotoClientInterface) {
+	rs.photoClient = photoClient
+}
+
+// AddEnricher registers an enricher. Enrichers run in registration order.
+func (rs *ReportService) AddEnricher(enricher Enricher) {
+	rs.enrichers = append(rs.enrichers, enricher)
+}
+
 // GetAllStudents retrieves a list of all students with optional filtering
 func (rs *ReportService) GetAllStudents(filters map[string]string) ([]models.StudentListItem, error) {
+	if rs.config.SafeMode {
+		return nil, ErrDisabledInSafeMode
+	}
+
+	if err := rs.checkFilters(filters); err != nil {
+		return nil, err
+	}
+
 	students, err := rs.nodeClient.GetAllStudents(filters)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch students list: %w", err)
@@ -46,39 +92,268 @@ func (rs *ReportService) GetAllStudents(filters map[string]string) ([]models.Stu
 	return students, nil
 }
 
-// GenerateStudentReport generates a complete student report
+// checkFilters rejects filter keys that the filter policy doesn't permit
+func (rs *ReportService) checkFilters(filters map[string]string) error {
+	var rejected []string
+	for key := range filters {
+		if !rs.config.Filters.FilterAllowed(key) {
+			rejected = append(rejected, key)
+		}
+	}
+
+	if len(rejected) > 0 {
+		sort.Strings(rejected)
+		return fmt.Errorf("%w: %s", ErrFilterNotAllowed, strings.Join(rejected, ", "))
+	}
+	return nil
+}
+
+// ExportStudentsCSV writes the filtered student list to w as CSV
+func (rs *ReportService) ExportStudentsCSV(filters map[string]string, w io.Writer) error {
+	mode, err := export.ParseQuoteMode(rs.config.Report.CSVQuoting)
+	if err != nil {
+		return err
+	}
+
+	students, err := rs.GetAllStudents(filters)
+	if err != nil {
+		return err
+	}
+
+	if err := export.WriteStudentsCSV(w, students, mode); err != nil {
+		return fmt.Errorf("failed to export students CSV: %w", err)
+	}
+
+	return nil
+}
+
+// csvFlushInterval is how many streamed CSV rows are written between flushes
+const csvFlushInterval = 500
+
+// StreamAllStudentsCSV writes the filtered student list to w as CSV while it
+// is being received from the Node.js API, flushing every csvFlushInterval rows.
+// Memory use does not grow with the number of students. If ctx is cancelled
+// the export stops and w holds a partial file.
+func (rs *ReportService) StreamAllStudentsCSV(ctx context.Context, w io.Writer, filters map[string]string) error {
+	if rs.config.SafeMode {
+		return ErrDisabledInSafeMode
+	}
+
+	if err := rs.checkFilters(filters); err != nil {
+		return err
+	}
+
+	mode, err := export.ParseQuoteMode(rs.config.Report.CSVQuoting)
+	if err != nil {
+		return err
+	}
+
+	cw := export.NewCSVWriter(w, mode)
+	if err := cw.Write(export.StudentCSVHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	rows := 0
+	err = rs.nodeClient.StreamAllStudents(ctx, filters, func(student models.StudentListItem) error {
+		if err := cw.Write(export.StudentCSVRecord(student)); err != nil {
+			return fmt.Errorf("failed to write CSV record for student %d: %w", student.ID, err)
+		}
+
+		rows++
+		if rows%csvFlushInterval == 0 {
+			return cw.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stream students CSV: %w", err)
+	}
+
+	return cw.Flush()
+}
+
+// CheckGeneratedBy rejects reserved generatedBy names from external callers.
+// The generate methods don't check it, so the service's own reports and
+// trusted internal callers can still use reserved names.
+func (rs *ReportService) CheckGeneratedBy(generatedBy string) error {
+	if rs.config.Report.GeneratedByBlocked(generatedBy) {
+		return fmt.Errorf("%w: %q", ErrGeneratedByNotAllowed, generatedBy)
+	}
+	return nil
+}
+
+// ReportOptions are the per-request options of a report
+type ReportOptions struct {
+	// GeneratedBy is recorded as the report's author
+	GeneratedBy string
+	// OnBehalfOf optionally records who the report was generated for
+	OnBehalfOf string
+	// Profile names the report profile; empty means the default profile
+	Profile string
+	// ReportContext is the report status and requesting role, which select
+	// the conditional watermark and the required options
+	ReportContext config.ReportContext
+}
+
+// GenerateStudentReport generates a complete student report using the default profile
 func (rs *ReportService) GenerateStudentReport(studentID int, generatedBy string) (*ReportResult, error) {
+	return rs.GenerateStudentReportWithProfile(context.Background(), studentID, ReportOptions{GeneratedBy: generatedBy})
+}
+
+// GenerateStudentReportWithProfile generates a student report using the
+// report profile and other options in opts. Retries stop when ctx is cancelled.
+func (rs *ReportService) GenerateStudentReportWithProfile(ctx context.Context, studentID int, opts ReportOptions) (*ReportResult, error) {
+	if rs.config.Report.ReadOnly {
+		return nil, ErrReadOnly
+	}
+
+	if rs.config.SafeMode {
+		return nil, ErrDisabledInSafeMode
+	}
+
 	if studentID <= 0 {
 		return nil, fmt.Errorf("invalid student ID: %d", studentID)
 	}
 
-	// Step 1: Fetch student data from Node.js API
-	student, err := rs.nodeClient.GetStudentByID(studentID)
+	profile, err := rs.resolveProfile(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pre-generated reports for the warm list carry no delegation, status or
+	// role, so only plain default-profile requests use them
+	usePregenerated := opts.OnBehalfOf == "" && opts.ReportContext.IsZero() && (opts.Profile == "" || opts.Profile == config.DefaultProfileName)
+
+	// The whole pipeline is re-run on retryable failures when retries are enabled
+	var result *ReportResult
+	var pregenerated bool
+	attempts, err := rs.retryPipeline(ctx, func() error {
+		// Step 1: Fetch student data from Node.js API
+		student, err := rs.nodeClient.GetStudentByID(studentID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch student data: %w", err)
+		}
+
+		if student == nil {
+			return fmt.Errorf("%w: ID %d", ErrStudentNotFound, studentID)
+		}
+
+		// Serve the pre-generated report without re-rendering when it was
+		// rendered from the same data
+		if usePregenerated {
+			if cached := rs.pregeneratedReport(student); cached != nil {
+				result, pregenerated = cached, true
+				return nil
+			}
+		}
+
+		result, err = rs.renderReport(student, opts.GeneratedBy, opts.OnBehalfOf, profile)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch student data: %w", err)
+		return nil, err
+	}
+
+	if !pregenerated {
+		result.Attempts = attempts
+	}
+	return result, nil
+}
+
+// GenerateReportFromStudent generates a report from supplied student data
+// instead of fetching it from the Node.js API, so it also works in safe mode.
+// ctx and opts are those of GenerateStudentReportWithProfile.
+func (rs *ReportService) GenerateReportFromStudent(ctx context.Context, student *models.Student, opts ReportOptions) (*ReportResult, error) {
+	if rs.config.Report.ReadOnly {
+		return nil, ErrReadOnly
 	}
 
 	if student == nil {
-		return nil, fmt.Errorf("student with ID %d not found", studentID)
+		return nil, fmt.Errorf("invalid student: no data provided")
+	}
+	if student.ID <= 0 {
+		return nil, fmt.Errorf("invalid student ID: %d", student.ID)
 	}
 
-	// Step 2: Create report metadata
-	metadata := &models.ReportMetadata{
-		GeneratedAt: time.Now(),
-		GeneratedBy: generatedBy,
-		ReportID:    fmt.Sprintf("RPT-%d-%d", studentID, time.Now().Unix()),
+	profile, err := rs.resolveProfile(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each attempt renders a fresh copy, as rendering converts its timestamps
+	var result *ReportResult
+	attempts, err := rs.retryPipeline(ctx, func() error {
+		studentCopy := *student
+		var err error
+		result, err = rs.renderReport(&studentCopy, opts.GeneratedBy, opts.OnBehalfOf, profile)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Step 3: Generate PDF report
-	filePath, err := rs.pdfGenerator.GenerateStudentReport(student, metadata)
+	result.Attempts = attempts
+	return result, nil
+}
+
+// resolveProfile returns the profile a request renders with, its conditional
+// watermark applied, after checking the request sets the required options
+func (rs *ReportService) resolveProfile(opts ReportOptions) (config.ReportProfile, error) {
+	profile, err := rs.config.Report.Profile(opts.Profile)
+	if err != nil {
+		return config.ReportProfile{}, err
+	}
+	profile = rs.applyWatermarkCondition(profile, opts.ReportContext)
+	if err := rs.checkRequiredOptions(opts, profile); err != nil {
+		return config.ReportProfile{}, err
+	}
+	return profile, nil
+}
+
+// applyWatermarkCondition sets the profile's watermark from the conditional
+// watermark rules, unless the profile sets a watermark of its own
+func (rs *ReportService) applyWatermarkCondition(profile config.ReportProfile, reportContext config.ReportContext) config.ReportProfile {
+	if profile.Watermark != nil {
+		return profile
+	}
+	if text, ok := rs.config.Report.ConditionalWatermark(reportContext); ok {
+		profile.Watermark = &text
+	}
+	return profile
+}
+
+// renderReport renders a report for an already fetched student
+func (rs *ReportService) renderReport(student *models.Student, generatedBy, onBehalfOf string, profile config.ReportProfile) (*ReportResult, error) {
+	studentID := student.ID
+
+	// Steps 2-4: Create report metadata, run enrichers and fetch the photo
+	reportID := pdf.StudentReportID(studentID, time.Now(), profile.Format)
+	metadata, warnings, err := rs.prepareMetadata(student, generatedBy, onBehalfOf, profile, reportID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate PDF report: %w", err)
+		return nil, err
 	}
 
-	// Step 4: Get actual file size
+	// Step 5: Generate the report in the profile's format
+	var filePath string
+	kind := "PDF"
+	switch profile.Format {
+	case config.ReportFormatText:
+		kind = "text"
+		filePath, err = rs.pdfGenerator.GenerateStudentTextReport(student, metadata)
+	default:
+		filePath, err = rs.pdfGenerator.GenerateStudentReport(student, metadata)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate %s report: %w", kind, err)
+	}
+	if metadata.Truncated {
+		warnings = append(warnings, rs.truncationWarning())
+	}
+
+	// Step 6: Get actual file size
 	fileSize := rs.getActualFileSize(filePath)
 
-	// Step 5: Create result
+	// Step 7: Create result
 	result := &ReportResult{
 		ReportID:    metadata.ReportID,
 		StudentID:   studentID,
@@ -86,62 +361,181 @@ func (rs *ReportService) GenerateStudentReport(studentID int, generatedBy string
 		FilePath:    filePath,
 		GeneratedAt: metadata.GeneratedAt,
 		GeneratedBy: generatedBy,
+		OnBehalfOf:  onBehalfOf,
 		FileSize:    fileSize,
+		Warnings:    warnings,
 	}
 
 	return result, nil
 }
 
-// HealthCheck performs a comprehensive health check
+// prepareMetadata builds the metadata a student's report is rendered with:
+// profile options, enricher fields, the cover letter and the student photo
+func (rs *ReportService) prepareMetadata(student *models.Student, generatedBy, onBehalfOf string, profile config.ReportProfile, reportID string) (*models.ReportMetadata, []string, error) {
+	display, err := rs.config.Report.DisplayLocation()
+	if err != nil {
+		return nil, nil, err
+	}
+	upstream, err := rs.config.Report.UpstreamLocation()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Step 2: Create report metadata and read the upstream dates in the upstream zone
+	metadata := &models.ReportMetadata{
+		GeneratedAt: time.Now().In(display),
+		GeneratedBy: generatedBy,
+		OnBehalfOf:  onBehalfOf,
+		ReportID:    reportID,
+		Watermark:   profile.Watermark,
+		Unsigned:    !rs.config.Report.SignsReports(profile),
+	}
+	warnings := localizeDates(student, upstream)
+
+	// Step 3: Let enrichers add data from other systems
+	for i, enricher := range rs.enrichers {
+		if err := enricher.Enrich(student, metadata); err != nil {
+			if rs.config.Report.EnricherFailureMode != config.EnricherFailureWarn {
+				return nil, nil, fmt.Errorf("enricher %d failed: %w", i+1, err)
+			}
+			warnings = append(warnings, fmt.Sprintf("enricher %d failed: %v", i+1, err))
+		}
+	}
+
+	if profile.CoverLetter != nil {
+		letter, err := rs.renderCoverLetter(student, metadata, profile.CoverLetter)
+		if err != nil {
+			return nil, nil, err
+		}
+		metadata.CoverLetter = letter
+	}
+
+	// Step 4: Fetch the student photo, if the photo service is configured
+	// and not disabled by safe mode. A missing photo (nil) renders as a
+	// placeholder, and so does a failed fetch, with a warning.
+	if rs.photoClient != nil && !rs.config.SafeMode {
+		photo, err := rs.photoClient.GetStudentPhoto(student.ID)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("failed to fetch student photo: %v", err))
+			photo = nil
+		}
+		metadata.ShowPhoto = true
+		metadata.Photo = photo
+	}
+
+	return metadata, warnings, nil
+}
+
+// HealthCheck probes the components enabled in the health configuration.
+// Disabled components are omitted and don't affect Healthy. In safe mode the
+// Node.js API is never probed.
 func (rs *ReportService) HealthCheck() *HealthStatus {
 	status := &HealthStatus{
 		Service:    "Report Service",
 		Timestamp:  time.Now(),
 		Healthy:    true,
+		Status:     HealthStatusHealthy,
+		ReadOnly:   rs.config.Report.ReadOnly,
+		SafeMode:   rs.config.SafeMode,
 		Components: make(map[string]ComponentStatus),
 	}
 
 	// Check Node.js API connectivity
-	if err := rs.nodeClient.HealthCheck(); err != nil {
-		status.Healthy = false
-		status.Components["nodejs_api"] = ComponentStatus{
-			Status:  "unhealthy",
-			Message: err.Error(),
-		}
-	} else {
-		status.Components["nodejs_api"] = ComponentStatus{
-			Status:  "healthy",
-			Message: "API is responsive",
+	if rs.config.Health.HealthComponentEnabled(config.HealthComponentNodeJSAPI) && !rs.config.SafeMode {
+		if err := rs.nodeClient.HealthCheck(); err != nil {
+			rs.componentFailed(status, config.HealthComponentNodeJSAPI, err.Error())
+		} else {
+			status.Components[config.HealthComponentNodeJSAPI] = ComponentStatus{
+				Status:  HealthStatusHealthy,
+				Message: "API is responsive",
+			}
 		}
 	}
 
 	// Check PDF generator (output directory)
-	if generator := rs.pdfGenerator; generator != nil {
-		status.Components["pdf_generator"] = ComponentStatus{
-			Status:  "healthy",
-			Message: "Generator is ready",
-		}
-	} else {
-		status.Healthy = false
-		status.Components["pdf_generator"] = ComponentStatus{
-			Status:  "unhealthy",
-			Message: "Generator not initialized",
+	if rs.config.Health.HealthComponentEnabled(config.HealthComponentPDFGenerator) {
+		if generator := rs.pdfGenerator; generator != nil {
+			status.Components[config.HealthComponentPDFGenerator] = ComponentStatus{
+				Status:  HealthStatusHealthy,
+				Message: "Generator is ready",
+			}
+		} else {
+			rs.componentFailed(status, config.HealthComponentPDFGenerator, "Generator not initialized")
 		}
 	}
 
 	// Set overall status message
-	if status.Healthy {
+	switch status.Status {
+	case HealthStatusHealthy:
 		status.Message = "All systems operational"
-	} else {
+	case HealthStatusDegraded:
+		status.Message = "Some non-critical components are unhealthy"
+	default:
 		status.Message = "Some components are unhealthy"
 	}
 
 	return status
 }
 
+// componentFailed records an unhealthy component. A critical component
+// makes the service unhealthy; any other only degrades it.
+func (rs *ReportService) componentFailed(status *HealthStatus, name, message string) {
+	status.Components[name] = ComponentStatus{
+		Status:  HealthStatusUnhealthy,
+		Message: message,
+	}
+
+	if rs.config.Health.HealthComponentCritical(name) {
+		status.Status = HealthStatusUnhealthy
+		status.Healthy = false
+	} else if status.Status == HealthStatusHealthy {
+		status.Status = HealthStatusDegraded
+	}
+}
+
 // CleanupOldReports cleans up old report files
-func (rs *ReportService) CleanupOldReports() error {
-	return rs.pdfGenerator.CleanupOldReports()
+func (rs *ReportService) CleanupOldReports(ctx context.Context) (*pdf.CleanupSummary, error) {
+	if rs.config.Report.ReadOnly {
+		return nil, ErrReadOnly
+	}
+	return rs.pdfGenerator.CleanupOldReports(ctx)
+}
+
+// RebuildReportIndex rebuilds the report index from the output directory
+// and returns how many reports it holds
+func (rs *ReportService) RebuildReportIndex() (int, error) {
+	return rs.pdfGenerator.RebuildIndex()
+}
+
+// ExtractReportText returns the plain text content of a generated report,
+// in reading order, for search indexing
+func (rs *ReportService) ExtractReportText(reportID string) (string, error) {
+	filePath, err := rs.pdfGenerator.FindReport(reportID)
+	if err != nil {
+		return "", err
+	}
+
+	text, err := pdf.ReportText(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to extract report text: %w", err)
+	}
+
+	return text, nil
+}
+
+// VerifyReport checks a generated report against its signed verification record
+func (rs *ReportService) VerifyReport(reportID string) error {
+	filePath, err := rs.pdfGenerator.FindReport(reportID)
+	if err != nil {
+		return err
+	}
+
+	return rs.pdfGenerator.VerifyWithRecord(filePath, pdf.VerificationRecordPath(filePath))
+}
+
+// truncationWarning is recorded on reports that were cut short at the page limit
+func (rs *ReportService) truncationWarning() string {
+	return fmt.Sprintf("report truncated at the %d-page limit", rs.config.Report.MaxPages)
 }
 
 // getActualFileSize gets the actual file size for the generated report
@@ -160,15 +554,31 @@ type ReportResult struct {
 	FilePath    string    `json:"file_path"`
 	GeneratedAt time.Time `json:"generated_at"`
 	GeneratedBy string    `json:"generated_by"`
+	OnBehalfOf  string    `json:"on_behalf_of,omitempty"`
 	FileSize    int64     `json:"file_size"`
+	Warnings    []string  `json:"warnings,omitempty"`
+	// Attempts is how many times the pipeline ran to produce the report; 0
+	// for pre-generated reports
+	Attempts int `json:"attempts,omitempty"`
 }
 
-// HealthStatus represents the health status of the service
+// Overall and component health states
+const (
+	HealthStatusHealthy   = "healthy"
+	HealthStatusDegraded  = "degraded"
+	HealthStatusUnhealthy = "unhealthy"
+)
+
+// HealthStatus represents the health status of the service. A degraded
+// service is still healthy: only critical component failures make it unhealthy.
 type HealthStatus struct {
 	Service    string                     `json:"service"`
 	Healthy    bool                       `json:"healthy"`
+	Status     string                     `json:"status"`
 	Message    string                     `json:"message"`
 	Timestamp  time.Time                  `json:"timestamp"`
+	ReadOnly   bool                       `json:"read_only"`
+	SafeMode   bool                       `json:"safe_mode"`
 	Components map[string]ComponentStatus `json:"components"`
 }
 
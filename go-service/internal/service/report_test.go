@@ -1,14 +1,21 @@
 package service
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"student-report-service/internal/config"
 	"student-report-service/internal/models"
+	"student-report-service/internal/pdf"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockNodeJSClient implements NodeJSClientInterface for testing
@@ -32,6 +39,18 @@ func (m *MockNodeJSClient) GetAllStudents(filters map[string]string) ([]models.S
 	return args.Get(0).([]models.StudentListItem), args.Error(1)
 }
 
+func (m *MockNodeJSClient) StreamAllStudents(ctx context.Context, filters map[string]string, fn func(models.StudentListItem) error) error {
+	args := m.Called(ctx, filters)
+	if students, ok := args.Get(0).([]models.StudentListItem); ok {
+		for _, student := range students {
+			if err := fn(student); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
 func (m *MockNodeJSClient) HealthCheck() error {
 	args := m.Called()
 	return args.Error(0)
@@ -52,11 +71,49 @@ func (m *MockPDFGenerator) GenerateStudentReport(student *models.Student, metada
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockPDFGenerator) CleanupOldReports() error {
-	args := m.Called()
+func (m *MockPDFGenerator) GenerateStudentTextReport(student *models.Student, metadata *models.ReportMetadata) (string, error) {
+	args := m.Called(student, metadata)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPDFGenerator) GenerateHouseholdReport(members []models.HouseholdMember, failures []models.HouseholdFailure, metadata *models.ReportMetadata) (string, error) {
+	args := m.Called(members, failures, metadata)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPDFGenerator) FindReport(reportID string) (string, error) {
+	args := m.Called(reportID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPDFGenerator) VerifyWithRecord(reportPath, recordPath string) error {
+	args := m.Called(reportPath, recordPath)
 	return args.Error(0)
 }
 
+func (m *MockPDFGenerator) RebuildIndex() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPDFGenerator) CleanupOldReports(ctx context.Context) (*pdf.CleanupSummary, error) {
+	args := m.Called(ctx)
+	summary, _ := args.Get(0).(*pdf.CleanupSummary)
+	return summary, args.Error(1)
+}
+
+type MockPhotoClient struct {
+	mock.Mock
+}
+
+func (m *MockPhotoClient) GetStudentPhoto(studentID int) (*models.StudentPhoto, error) {
+	args := m.Called(studentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StudentPhoto), args.Error(1)
+}
+
 func TestReportService_GenerateStudentReport(t *testing.T) {
 	mockStudent := &models.Student{
 		ID:    1,
@@ -156,23 +213,61 @@ func TestReportService_GenerateStudentReport(t *testing.T) {
 
 func TestReportService_HealthCheck(t *testing.T) {
 	tests := []struct {
-		name            string
-		setupMocks      func(*MockNodeJSClient, *MockPDFGenerator)
-		expectedHealthy bool
+		name               string
+		components         []string
+		critical           []string
+		setupMocks         func(*MockNodeJSClient, *MockPDFGenerator)
+		expectedHealthy    bool
+		expectedStatus     string
+		expectedComponents []string
 	}{
 		{
 			name: "All components healthy",
 			setupMocks: func(nodeClient *MockNodeJSClient, pdfGen *MockPDFGenerator) {
 				nodeClient.On("HealthCheck").Return(nil)
 			},
-			expectedHealthy: true,
+			expectedHealthy:    true,
+			expectedStatus:     HealthStatusHealthy,
+			expectedComponents: []string{"nodejs_api", "pdf_generator"},
 		},
 		{
 			name: "Node.js API unhealthy",
 			setupMocks: func(nodeClient *MockNodeJSClient, pdfGen *MockPDFGenerator) {
 				nodeClient.On("HealthCheck").Return(errors.New("API unavailable"))
 			},
-			expectedHealthy: false,
+			expectedHealthy:    false,
+			expectedStatus:     HealthStatusUnhealthy,
+			expectedComponents: []string{"nodejs_api", "pdf_generator"},
+		},
+		{
+			name:     "Non-critical failure degrades the service",
+			critical: []string{config.HealthComponentPDFGenerator},
+			setupMocks: func(nodeClient *MockNodeJSClient, pdfGen *MockPDFGenerator) {
+				nodeClient.On("HealthCheck").Return(errors.New("API unavailable"))
+			},
+			expectedHealthy:    true,
+			expectedStatus:     HealthStatusDegraded,
+			expectedComponents: []string{"nodejs_api", "pdf_generator"},
+		},
+		{
+			name:     "Critical failure makes the service unhealthy",
+			critical: []string{config.HealthComponentNodeJSAPI},
+			setupMocks: func(nodeClient *MockNodeJSClient, pdfGen *MockPDFGenerator) {
+				nodeClient.On("HealthCheck").Return(errors.New("API unavailable"))
+			},
+			expectedHealthy:    false,
+			expectedStatus:     HealthStatusUnhealthy,
+			expectedComponents: []string{"nodejs_api", "pdf_generator"},
+		},
+		{
+			name:       "Disabled component is omitted",
+			components: []string{config.HealthComponentPDFGenerator},
+			setupMocks: func(nodeClient *MockNodeJSClient, pdfGen *MockPDFGenerator) {
+				// The unhealthy API must not be probed
+			},
+			expectedHealthy:    true,
+			expectedStatus:     HealthStatusHealthy,
+			expectedComponents: []string{"pdf_generator"},
 		},
 	}
 
@@ -186,7 +281,7 @@ func TestReportService_HealthCheck(t *testing.T) {
 			tt.setupMocks(mockNodeClient, mockPDFGen)
 
 			// Create service
-			cfg := &config.Config{}
+			cfg := &config.Config{Health: config.HealthConfig{Components: tt.components, Critical: tt.critical}}
 			service := NewReportService(mockNodeClient, mockPDFGen, cfg)
 
 			// Execute
@@ -194,8 +289,12 @@ func TestReportService_HealthCheck(t *testing.T) {
 
 			// Verify
 			assert.Equal(t, tt.expectedHealthy, status.Healthy)
+			assert.Equal(t, tt.expectedStatus, status.Status)
 			assert.Equal(t, "Report Service", status.Service)
-			assert.NotEmpty(t, status.Components)
+			assert.Len(t, status.Components, len(tt.expectedComponents))
+			for _, component := range tt.expectedComponents {
+				assert.Contains(t, status.Components, component)
+			}
 
 			// Assert that all expectations were met
 			mockNodeClient.AssertExpectations(t)
@@ -213,14 +312,14 @@ func TestReportService_CleanupOldReports(t *testing.T) {
 		{
 			name: "Successful cleanup",
 			setupMocks: func(pdfGen *MockPDFGenerator) {
-				pdfGen.On("CleanupOldReports").Return(nil)
+				pdfGen.On("CleanupOldReports", mock.Anything).Return(&pdf.CleanupSummary{Deleted: 2, Skipped: 1}, nil)
 			},
 			expectedError: false,
 		},
 		{
 			name: "Cleanup fails",
 			setupMocks: func(pdfGen *MockPDFGenerator) {
-				pdfGen.On("CleanupOldReports").Return(errors.New("cleanup failed"))
+				pdfGen.On("CleanupOldReports", mock.Anything).Return(nil, errors.New("cleanup failed"))
 			},
 			expectedError: true,
 		},
@@ -240,13 +339,14 @@ func TestReportService_CleanupOldReports(t *testing.T) {
 			service := NewReportService(mockNodeClient, mockPDFGen, cfg)
 
 			// Execute
-			err := service.CleanupOldReports()
+			summary, err := service.CleanupOldReports(context.Background())
 
 			// Verify
 			if tt.expectedError {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, &pdf.CleanupSummary{Deleted: 2, Skipped: 1}, summary)
 			}
 
 			// Assert that all expectations were met
@@ -359,3 +459,608 @@ func stringPtr(s string) *string {
 func intPtr(i int) *int {
 	return &i
 }
+
+func TestReportService_GenerateStudentReportWithProfile(t *testing.T) {
+	mockStudent := &models.Student{ID: 1, Name: "John Doe"}
+	draft := "DRAFT"
+
+	cfg := &config.Config{
+		Report: config.ReportConfig{
+			Profiles: map[string]config.ReportProfile{
+				"draft": {Format: "pdf", Watermark: &draft},
+				"text":  {Format: config.ReportFormatText},
+			},
+		},
+	}
+
+	t.Run("Profile options are applied", func(t *testing.T) {
+		mockNodeClient := new(MockNodeJSClient)
+		mockPDFGen := new(MockPDFGenerator)
+
+		mockNodeClient.On("GetStudentByID", 1).Return(mockStudent, nil)
+		mockPDFGen.On("GenerateStudentReport", mockStudent, mock.MatchedBy(func(metadata *models.ReportMetadata) bool {
+			return metadata.Watermark != nil && *metadata.Watermark == "DRAFT"
+		})).Return("/path/to/report.pdf", nil)
+
+		service := NewReportService(mockNodeClient, mockPDFGen, cfg)
+		result, err := service.GenerateStudentReportWithProfile(context.Background(), 1, ReportOptions{GeneratedBy: "Test User", Profile: "draft"})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockNodeClient.AssertExpectations(t)
+		mockPDFGen.AssertExpectations(t)
+	})
+
+	t.Run("Default profile keeps configured defaults", func(t *testing.T) {
+		mockNodeClient := new(MockNodeJSClient)
+		mockPDFGen := new(MockPDFGenerator)
+
+		mockNodeClient.On("GetStudentByID", 1).Return(mockStudent, nil)
+		mockPDFGen.On("GenerateStudentReport", mockStudent, mock.MatchedBy(func(metadata *models.ReportMetadata) bool {
+			return metadata.Watermark == nil
+		})).Return("/path/to/report.pdf", nil)
+
+		service := NewReportService(mockNodeClient, mockPDFGen, cfg)
+		_, err := service.GenerateStudentReportWithProfile(context.Background(), 1, ReportOptions{GeneratedBy: "Test User", Profile: "default"})
+
+		assert.NoError(t, err)
+		mockPDFGen.AssertExpectations(t)
+	})
+
+	t.Run("Text format", func(t *testing.T) {
+		mockNodeClient := new(MockNodeJSClient)
+		mockPDFGen := new(MockPDFGenerator)
+
+		mockNodeClient.On("GetStudentByID", 1).Return(mockStudent, nil)
+		mockPDFGen.On("GenerateStudentTextReport", mockStudent, mock.AnythingOfType("*models.ReportMetadata")).Return("/path/to/report.txt", nil)
+
+		service := NewReportService(mockNodeClient, mockPDFGen, cfg)
+		result, err := service.GenerateStudentReportWithProfile(context.Background(), 1, ReportOptions{GeneratedBy: "Test User", Profile: "text"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "/path/to/report.txt", result.FilePath)
+		assert.Regexp(t, `^RPT-1-[0-9]+-TXT$`, result.ReportID)
+		mockPDFGen.AssertNotCalled(t, "GenerateStudentReport", mock.Anything, mock.Anything)
+		mockPDFGen.AssertExpectations(t)
+	})
+
+	t.Run("Watermark conditions", func(t *testing.T) {
+		ruleCfg := &config.Config{Report: config.ReportConfig{
+			WatermarkRulesSpec: "draft=DRAFT,final:staff=INTERNAL COPY",
+			Profiles:           cfg.Report.Profiles,
+		}}
+		require.NoError(t, ruleCfg.Report.LoadWatermarkRules())
+
+		tests := []struct {
+			name          string
+			profile       string
+			reportContext config.ReportContext
+			expected      string
+		}{
+			{name: "Status rule", reportContext: config.ReportContext{Status: "draft"}, expected: "DRAFT"},
+			{name: "Role rule", reportContext: config.ReportContext{Role: "staff"}, expected: "INTERNAL COPY"},
+			{name: "No matching rule", reportContext: config.ReportContext{Role: "parent"}, expected: ""},
+			{name: "Profile watermark wins", profile: "draft", reportContext: config.ReportContext{Role: "staff"}, expected: "DRAFT"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockNodeClient := new(MockNodeJSClient)
+				mockPDFGen := new(MockPDFGenerator)
+
+				mockNodeClient.On("GetStudentByID", 1).Return(mockStudent, nil)
+				mockPDFGen.On("GenerateStudentReport", mockStudent, mock.MatchedBy(func(metadata *models.ReportMetadata) bool {
+					return metadata.Watermark != nil && *metadata.Watermark == tt.expected
+				})).Return("/path/to/report.pdf", nil)
+
+				service := NewReportService(mockNodeClient, mockPDFGen, ruleCfg)
+				_, err := service.GenerateStudentReportWithProfile(context.Background(), 1, ReportOptions{GeneratedBy: "Test User", Profile: tt.profile, ReportContext: tt.reportContext})
+
+				assert.NoError(t, err)
+				mockPDFGen.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("Unknown profile", func(t *testing.T) {
+		mockNodeClient := new(MockNodeJSClient)
+		mockPDFGen := new(MockPDFGenerator)
+
+		service := NewReportService(mockNodeClient, mockPDFGen, cfg)
+		result, err := service.GenerateStudentReportWithProfile(context.Background(), 1, ReportOptions{GeneratedBy: "Test User", Profile: "missing"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `report profile "missing" not found`)
+		assert.Nil(t, result)
+		mockNodeClient.AssertExpectations(t)
+	})
+}
+
+func TestReportService_Enrichers(t *testing.T) {
+	mockStudent := &models.Student{ID: 1, Name: "John Doe"}
+
+	busRoute := EnricherFunc(func(student *models.Student, metadata *models.ReportMetadata) error {
+		metadata.AddExtraField("Bus Route", "42")
+		return nil
+	})
+	locker := EnricherFunc(func(student *models.Student, metadata *models.ReportMetadata) error {
+		metadata.AddExtraField("Locker", "B-12")
+		return nil
+	})
+	failing := EnricherFunc(func(student *models.Student, metadata *models.ReportMetadata) error {
+		return errors.New("locker system unavailable")
+	})
+
+	tests := []struct {
+		name             string
+		failureMode      string
+		enrichers        []Enricher
+		expectedError    bool
+		expectedFields   []models.ExtraField
+		expectedWarnings int
+	}{
+		{
+			name:      "Enrichers run in order",
+			enrichers: []Enricher{busRoute, locker},
+			expectedFields: []models.ExtraField{
+				{Label: "Bus Route", Value: "42"},
+				{Label: "Locker", Value: "B-12"},
+			},
+		},
+		{
+			name:          "Failure aborts by default",
+			enrichers:     []Enricher{busRoute, failing},
+			expectedError: true,
+		},
+		{
+			name:             "Failure warns and continues",
+			failureMode:      config.EnricherFailureWarn,
+			enrichers:        []Enricher{failing, locker},
+			expectedFields:   []models.ExtraField{{Label: "Locker", Value: "B-12"}},
+			expectedWarnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNodeClient := new(MockNodeJSClient)
+			mockPDFGen := new(MockPDFGenerator)
+
+			var rendered *models.ReportMetadata
+			mockNodeClient.On("GetStudentByID", 1).Return(mockStudent, nil)
+			mockPDFGen.On("GenerateStudentReport", mockStudent, mock.AnythingOfType("*models.ReportMetadata")).
+				Run(func(args mock.Arguments) { rendered = args.Get(1).(*models.ReportMetadata) }).
+				Return("/path/to/report.pdf", nil).Maybe()
+
+			cfg := &config.Config{Report: config.ReportConfig{EnricherFailureMode: tt.failureMode}}
+			service := NewReportService(mockNodeClient, mockPDFGen, cfg)
+			for _, enricher := range tt.enrichers {
+				service.AddEnricher(enricher)
+			}
+
+			result, err := service.GenerateStudentReport(1, "Test User")
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "enricher 2 failed")
+				mockPDFGen.AssertNotCalled(t, "GenerateStudentReport", mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedFields, rendered.ExtraFields)
+			assert.Len(t, result.Warnings, tt.expectedWarnings)
+		})
+	}
+}
+
+func TestReportService_Photo(t *testing.T) {
+	mockStudent := &models.Student{ID: 1, Name: "John Doe"}
+	photo := &models.StudentPhoto{Data: []byte("jpeg"), ContentType: "image/jpeg"}
+
+	tests := []struct {
+		name          string
+		photo         *models.StudentPhoto
+		photoErr      error
+		expectedPhoto *models.StudentPhoto
+		expectedWarns []string
+	}{
+		{
+			name:          "Photo is embedded",
+			photo:         photo,
+			expectedPhoto: photo,
+		},
+		{
+			name: "No photo renders the placeholder",
+		},
+		{
+			name:          "Photo service failure warns and renders the placeholder",
+			photoErr:      errors.New("photo service returned status 503"),
+			expectedWarns: []string{"failed to fetch student photo: photo service returned status 503"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNodeClient := new(MockNodeJSClient)
+			mockPDFGen := new(MockPDFGenerator)
+			mockPhotoClient := new(MockPhotoClient)
+
+			var rendered *models.ReportMetadata
+			mockNodeClient.On("GetStudentByID", 1).Return(mockStudent, nil)
+			mockPhotoClient.On("GetStudentPhoto", 1).Return(tt.photo, tt.photoErr)
+			mockPDFGen.On("GenerateStudentReport", mockStudent, mock.AnythingOfType("*models.ReportMetadata")).
+				Run(func(args mock.Arguments) { rendered = args.Get(1).(*models.ReportMetadata) }).
+				Return("/path/to/report.pdf", nil)
+
+			service := NewReportService(mockNodeClient, mockPDFGen, &config.Config{})
+			service.SetPhotoClient(mockPhotoClient)
+
+			result, err := service.GenerateStudentReport(1, "Test User")
+
+			require.NoError(t, err)
+			assert.True(t, rendered.ShowPhoto)
+			assert.Equal(t, tt.expectedPhoto, rendered.Photo)
+			assert.Equal(t, tt.expectedWarns, result.Warnings)
+		})
+	}
+}
+
+func TestReportService_TruncatedReportWarning(t *testing.T) {
+	mockStudent := &models.Student{ID: 1, Name: "John Doe"}
+
+	mockNodeClient := new(MockNodeJSClient)
+	mockPDFGen := new(MockPDFGenerator)
+
+	mockNodeClient.On("GetStudentByID", 1).Return(mockStudent, nil)
+	mockPDFGen.On("GenerateStudentReport", mockStudent, mock.AnythingOfType("*models.ReportMetadata")).
+		Run(func(args mock.Arguments) { args.Get(1).(*models.ReportMetadata).Truncated = true }).
+		Return("/path/to/report.pdf", nil)
+
+	cfg := &config.Config{Report: config.ReportConfig{MaxPages: 50}}
+	service := NewReportService(mockNodeClient, mockPDFGen, cfg)
+
+	result, err := service.GenerateStudentReport(1, "Test User")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"report truncated at the 50-page limit"}, result.Warnings)
+}
+
+func TestReportService_StreamAllStudentsCSV(t *testing.T) {
+	className := "Grade 10"
+	students := []models.StudentListItem{
+		{ID: 1, Name: "Doe, John", Email: "john@example.com", SystemAccess: true, Class: &className},
+		{ID: 2, Name: `Jane "JJ" Smith`, Email: "jane@example.com"},
+	}
+
+	t.Run("Writes header and escaped rows", func(t *testing.T) {
+		mockNodeClient := new(MockNodeJSClient)
+		filters := map[string]string{"className": "Grade 10"}
+		mockNodeClient.On("StreamAllStudents", mock.Anything, filters).Return(students, nil)
+
+		service := NewReportService(mockNodeClient, new(MockPDFGenerator), &config.Config{})
+
+		var buf bytes.Buffer
+		err := service.StreamAllStudentsCSV(context.Background(), &buf, filters)
+
+		require.NoError(t, err)
+		assert.Equal(t, "id,name,email,systemAccess,class,section,roll\r\n"+
+			"1,\"Doe, John\",john@example.com,true,Grade 10,\"\",\"\"\r\n"+
+			"2,\"Jane \"\"JJ\"\" Smith\",jane@example.com,false,\"\",\"\",\"\"\r\n", buf.String())
+	})
+
+	t.Run("Stream error is returned", func(t *testing.T) {
+		mockNodeClient := new(MockNodeJSClient)
+		mockNodeClient.On("StreamAllStudents", mock.Anything, mock.Anything).Return(students[:1], context.Canceled)
+
+		service := NewReportService(mockNodeClient, new(MockPDFGenerator), &config.Config{})
+
+		err := service.StreamAllStudentsCSV(context.Background(), io.Discard, nil)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestReportService_ExtractReportText(t *testing.T) {
+	t.Run("Report not found", func(t *testing.T) {
+		mockPDFGen := new(MockPDFGenerator)
+		mockPDFGen.On("FindReport", "RPT-1-1").Return("", pdf.ErrReportNotFound)
+
+		service := NewReportService(new(MockNodeJSClient), mockPDFGen, &config.Config{})
+
+		_, err := service.ExtractReportText("RPT-1-1")
+		assert.ErrorIs(t, err, pdf.ErrReportNotFound)
+	})
+
+	t.Run("Text of found report", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "report.txt")
+		require.NoError(t, os.WriteFile(path, []byte("Report ID: RPT-1-1\nFull Name: John Doe\n"), 0644))
+
+		mockPDFGen := new(MockPDFGenerator)
+		mockPDFGen.On("FindReport", "RPT-1-1").Return(path, nil)
+
+		service := NewReportService(new(MockNodeJSClient), mockPDFGen, &config.Config{})
+
+		text, err := service.ExtractReportText("RPT-1-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Report ID: RPT-1-1\nFull Name: John Doe", text)
+	})
+}
+
+func TestReportService_VerifyReport(t *testing.T) {
+	t.Run("Report not found", func(t *testing.T) {
+		mockPDFGen := new(MockPDFGenerator)
+		mockPDFGen.On("FindReport", "RPT-1-1").Return("", pdf.ErrReportNotFound)
+
+		service := NewReportService(new(MockNodeJSClient), mockPDFGen, &config.Config{})
+
+		assert.ErrorIs(t, service.VerifyReport("RPT-1-1"), pdf.ErrReportNotFound)
+		mockPDFGen.AssertNotCalled(t, "VerifyWithRecord", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Report is checked against its record", func(t *testing.T) {
+		mockPDFGen := new(MockPDFGenerator)
+		mockPDFGen.On("FindReport", "RPT-1-1").Return("/reports/report.pdf", nil)
+		mockPDFGen.On("VerifyWithRecord", "/reports/report.pdf", pdf.VerificationRecordPath("/reports/report.pdf")).Return(pdf.ErrVerificationFailed)
+
+		service := NewReportService(new(MockNodeJSClient), mockPDFGen, &config.Config{})
+
+		assert.ErrorIs(t, service.VerifyReport("RPT-1-1"), pdf.ErrVerificationFailed)
+	})
+}
+
+func TestReportService_ReadOnly(t *testing.T) {
+	mockNodeClient := new(MockNodeJSClient)
+	mockPDFGen := new(MockPDFGenerator)
+	mockNodeClient.On("HealthCheck").Return(nil)
+
+	cfg := &config.Config{Report: config.ReportConfig{ReadOnly: true, WarmStudentIDs: "1"}}
+	service := NewReportService(mockNodeClient, mockPDFGen, cfg)
+
+	_, err := service.GenerateStudentReport(1, "Test User")
+	assert.ErrorIs(t, err, ErrReadOnly)
+
+	_, err = service.GenerateHouseholdReport([]int{1, 2}, ReportOptions{GeneratedBy: "Test User"})
+	assert.ErrorIs(t, err, ErrReadOnly)
+
+	assert.ErrorIs(t, service.RefreshPregeneratedReports(), ErrReadOnly)
+	_, err = service.CleanupOldReports(context.Background())
+	assert.ErrorIs(t, err, ErrReadOnly)
+
+	status := service.HealthCheck()
+	assert.True(t, status.Healthy)
+	assert.True(t, status.ReadOnly)
+
+	mockNodeClient.AssertNotCalled(t, "GetStudentByID", mock.Anything)
+	mockPDFGen.AssertNotCalled(t, "GenerateStudentReport", mock.Anything, mock.Anything)
+	mockPDFGen.AssertNotCalled(t, "CleanupOldReports", mock.Anything)
+}
+
+func TestReportService_SafeMode(t *testing.T) {
+	mockNodeClient := new(MockNodeJSClient)
+	mockPDFGen := new(MockPDFGenerator)
+	dob := "2010-05-01T08:00:00Z"
+	student := &models.Student{ID: 1, Name: "John Doe", DOB: &dob}
+	mockPDFGen.On("GenerateStudentReport", mock.AnythingOfType("*models.Student"), mock.AnythingOfType("*models.ReportMetadata")).Return("/path/to/report.pdf", nil)
+
+	cfg := &config.Config{SafeMode: true, Report: config.ReportConfig{WarmStudentIDs: "1"}}
+	service := NewReportService(mockNodeClient, mockPDFGen, cfg)
+
+	_, err := service.GenerateStudentReport(1, "Test User")
+	assert.ErrorIs(t, err, ErrDisabledInSafeMode)
+
+	_, err = service.GenerateHouseholdReport([]int{1, 2}, ReportOptions{GeneratedBy: "Test User"})
+	assert.ErrorIs(t, err, ErrDisabledInSafeMode)
+
+	_, err = service.GetAllStudents(nil)
+	assert.ErrorIs(t, err, ErrDisabledInSafeMode)
+	assert.ErrorIs(t, service.StreamAllStudentsCSV(context.Background(), io.Discard, nil), ErrDisabledInSafeMode)
+	assert.ErrorIs(t, service.RefreshPregeneratedReports(), ErrDisabledInSafeMode)
+
+	// Supplied student data is rendered locally, leaving the caller's copy unchanged
+	result, err := service.GenerateReportFromStudent(context.Background(), student, ReportOptions{GeneratedBy: "Test User"})
+	require.NoError(t, err)
+	assert.Equal(t, "/path/to/report.pdf", result.FilePath)
+	assert.Equal(t, "2010-05-01T08:00:00Z", *student.DOB)
+
+	status := service.HealthCheck()
+	assert.True(t, status.Healthy)
+	assert.True(t, status.SafeMode)
+	assert.NotContains(t, status.Components, config.HealthComponentNodeJSAPI)
+
+	mockNodeClient.AssertNotCalled(t, "GetStudentByID", mock.Anything)
+	mockNodeClient.AssertNotCalled(t, "HealthCheck")
+}
+
+func TestReportService_RequiredOptions(t *testing.T) {
+	unsigned := false
+
+	tests := []struct {
+		name          string
+		onBehalfOf    string
+		profile       string
+		reportContext config.ReportContext
+		secret        string
+		missing       []string
+	}{
+		{
+			name:    "Final report missing options",
+			missing: []string{config.RequiredOnBehalfOf, config.RequiredSignature},
+		},
+		{
+			name:       "Final report with all options",
+			onBehalfOf: "Principal",
+			secret:     "secret",
+		},
+		{
+			name:       "Final report with a profile that doesn't sign",
+			onBehalfOf: "Principal",
+			profile:    "unsigned",
+			secret:     "secret",
+			missing:    []string{config.RequiredSignature},
+		},
+		{
+			name:          "Draft report with role and watermark",
+			reportContext: config.ReportContext{Status: "draft", Role: "teacher"},
+		},
+		{
+			name:          "Draft report without role",
+			reportContext: config.ReportContext{Status: "draft"},
+			missing:       []string{config.RequiredRole},
+		},
+		{
+			name:          "Draft report whose watermark is removed",
+			reportContext: config.ReportContext{Status: "draft", Role: "parent"},
+			missing:       []string{config.RequiredWatermark},
+		},
+		{
+			name:          "Status without requirements",
+			reportContext: config.ReportContext{Status: "review"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNodeClient := new(MockNodeJSClient)
+			mockPDFGen := new(MockPDFGenerator)
+			mockNodeClient.On("GetStudentByID", 1).Return(&models.Student{ID: 1, Name: "John Doe"}, nil)
+			mockPDFGen.On("GenerateStudentReport", mock.AnythingOfType("*models.Student"), mock.AnythingOfType("*models.ReportMetadata")).Return("/path/to/report.pdf", nil)
+
+			cfg := &config.Config{Report: config.ReportConfig{
+				WatermarkRulesSpec:  "draft=DRAFT,draft:parent=",
+				RequiredOptionsSpec: "final=on_behalf_of+signature,draft=role+watermark",
+				VerificationSecret:  tt.secret,
+				Profiles:            map[string]config.ReportProfile{"unsigned": {Format: config.ReportFormatPDF, Sign: &unsigned}},
+			}}
+			require.NoError(t, cfg.Report.LoadWatermarkRules())
+			require.NoError(t, cfg.Report.LoadRequiredOptions())
+			service := NewReportService(mockNodeClient, mockPDFGen, cfg)
+
+			_, err := service.GenerateStudentReportWithProfile(context.Background(), 1, ReportOptions{GeneratedBy: "Test User", OnBehalfOf: tt.onBehalfOf, Profile: tt.profile, ReportContext: tt.reportContext})
+
+			if tt.missing == nil {
+				require.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.missing, validationErr.Missing)
+			assert.Contains(t, err.Error(), "invalid report options")
+			mockNodeClient.AssertNotCalled(t, "GetStudentByID", mock.Anything)
+		})
+	}
+}
+
+func TestReportService_FilterPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        config.FilterConfig
+		filters       map[string]string
+		expectedError string
+	}{
+		{
+			name:    "No policy allows every filter",
+			filters: map[string]string{"name": "John", "roll": "101"},
+		},
+		{
+			name:    "Allowlisted filters pass",
+			policy:  config.FilterConfig{Allowed: []string{"className", "section"}},
+			filters: map[string]string{"className": "Grade 10"},
+		},
+		{
+			name:          "Filters outside the allowlist are rejected",
+			policy:        config.FilterConfig{Allowed: []string{"className"}},
+			filters:       map[string]string{"className": "Grade 10", "roll": "101", "name": "John"},
+			expectedError: "student filter not allowed: name, roll",
+		},
+		{
+			name:          "Denylisted filters are rejected",
+			policy:        config.FilterConfig{Denied: []string{"name"}},
+			filters:       map[string]string{"name": "John"},
+			expectedError: "student filter not allowed: name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNodeClient := new(MockNodeJSClient)
+			mockNodeClient.On("GetAllStudents", tt.filters).Return([]models.StudentListItem{}, nil).Maybe()
+
+			service := NewReportService(mockNodeClient, new(MockPDFGenerator), &config.Config{Filters: tt.policy})
+
+			_, err := service.GetAllStudents(tt.filters)
+
+			if tt.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, ErrFilterNotAllowed)
+			assert.EqualError(t, err, tt.expectedError)
+			mockNodeClient.AssertNotCalled(t, "GetAllStudents", mock.Anything)
+
+			err = service.StreamAllStudentsCSV(context.Background(), io.Discard, tt.filters)
+			assert.ErrorIs(t, err, ErrFilterNotAllowed)
+		})
+	}
+}
+
+func TestReportService_CheckGeneratedBy(t *testing.T) {
+	tests := []struct {
+		name        string
+		blocklist   []string
+		generatedBy string
+		expectedErr bool
+	}{
+		{
+			name:        "Empty blocklist allows every name",
+			generatedBy: "admin",
+		},
+		{
+			name:        "Reserved name is rejected",
+			blocklist:   []string{"root", "admin"},
+			generatedBy: "admin",
+			expectedErr: true,
+		},
+		{
+			name:        "Comparison ignores case and surrounding spaces",
+			blocklist:   []string{"root", "admin"},
+			generatedBy: " Root ",
+			expectedErr: true,
+		},
+		{
+			name:        "Other names pass",
+			blocklist:   []string{"root", "admin"},
+			generatedBy: "Ms. Smith",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Report: config.ReportConfig{GeneratedByBlocklist: tt.blocklist}}
+			service := NewReportService(new(MockNodeJSClient), new(MockPDFGenerator), cfg)
+
+			err := service.CheckGeneratedBy(tt.generatedBy)
+
+			if tt.expectedErr {
+				assert.ErrorIs(t, err, ErrGeneratedByNotAllowed)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("Generating directly is not restricted", func(t *testing.T) {
+		mockNodeClient := new(MockNodeJSClient)
+		mockPDFGen := new(MockPDFGenerator)
+		mockNodeClient.On("GetStudentByID", 1).Return(&models.Student{ID: 1, Name: "John Doe"}, nil)
+		mockPDFGen.On("GenerateStudentReport", mock.AnythingOfType("*models.Student"), mock.AnythingOfType("*models.ReportMetadata")).Return("/path/to/report.pdf", nil)
+
+		cfg := &config.Config{Report: config.ReportConfig{GeneratedByBlocklist: []string{"admin"}}}
+		service := NewReportService(mockNodeClient, mockPDFGen, cfg)
+
+		result, err := service.GenerateStudentReport(1, "admin")
+
+		require.NoError(t, err)
+		assert.Equal(t, "admin", result.GeneratedBy)
+	})
+}
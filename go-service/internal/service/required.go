@@ -0,0 +1,69 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"student-report-service/internal/config"
+)
+
+// ValidationError is returned when a report request leaves out options the
+// required-options policy demands for its status and profile
+type ValidationError struct {
+	Status  string
+	Profile string
+	Missing []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid report options: %s reports with profile %q require %s",
+		e.Status, e.Profile, strings.Join(e.Missing, ", "))
+}
+
+// checkRequiredOptions validates a report request against the
+// required-options policy before anything is fetched or rendered. profile
+// must already have its conditional watermark applied.
+func (rs *ReportService) checkRequiredOptions(opts ReportOptions, profile config.ReportProfile) error {
+	profileName, reportContext := opts.Profile, opts.ReportContext
+	if profileName == "" {
+		profileName = config.DefaultProfileName
+	}
+
+	var missing []string
+	for _, option := range rs.config.Report.RequiredOptions(reportContext, profileName) {
+		if !rs.hasReportOption(option, opts.OnBehalfOf, profile, reportContext) {
+			missing = append(missing, option)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	status := strings.ToLower(strings.TrimSpace(reportContext.Status))
+	if status == "" {
+		status = config.ReportStatusFinal
+	}
+	return &ValidationError{Status: status, Profile: profileName, Missing: missing}
+}
+
+// hasReportOption reports whether a request sets a required option
+func (rs *ReportService) hasReportOption(option, onBehalfOf string, profile config.ReportProfile, reportContext config.ReportContext) bool {
+	switch option {
+	case config.RequiredOnBehalfOf:
+		return strings.TrimSpace(onBehalfOf) != ""
+	case config.RequiredRole:
+		return strings.TrimSpace(reportContext.Role) != ""
+	case config.RequiredCoverLetter:
+		return profile.CoverLetter != nil
+	case config.RequiredWatermark:
+		watermark := rs.config.Report.WatermarkText
+		if profile.Watermark != nil {
+			watermark = *profile.Watermark
+		}
+		return strings.TrimSpace(watermark) != ""
+	case config.RequiredSignature:
+		return rs.config.Report.SignsReports(profile)
+	default:
+		return false
+	}
+}
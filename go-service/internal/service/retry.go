@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"student-report-service/internal/client"
+	"student-report-service/internal/pdf"
+)
+
+// ErrStudentNotFound is returned when the Node.js API has no data for a student
+var ErrStudentNotFound = errors.New("student not found")
+
+// PipelineError is returned when report generation still fails after being
+// retried. Attempts counts every run, including the first.
+type PipelineError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *PipelineError) Error() string {
+	return fmt.Sprintf("report generation failed after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *PipelineError) Unwrap() error {
+	return e.Err
+}
+
+// retryPipeline runs fn, running it again on retryable failures up to the
+// configured number of retries. The wait before each retry doubles, starting
+// at the configured backoff, and ends early when ctx is cancelled. It returns
+// how many times fn ran.
+func (rs *ReportService) retryPipeline(ctx context.Context, fn func() error) (int, error) {
+	backoff := rs.config.Report.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return attempt, nil
+		}
+		if !isRetryable(err) || attempt > rs.config.Report.RetryAttempts {
+			if attempt > 1 {
+				err = &PipelineError{Attempts: attempt, Err: err}
+			}
+			return attempt, err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, &PipelineError{Attempts: attempt, Err: fmt.Errorf("%w; retry cancelled: %w", err, ctx.Err())}
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// isRetryable reports whether a failed report might succeed when generated
+// again. Invalid input, missing students, client errors from the Node.js API
+// and limits the report breaks fail the same way every time. Incomplete
+// responses have already been retried by the Node.js client.
+func isRetryable(err error) bool {
+	switch {
+	case errors.Is(err, client.ErrIncompleteResponse):
+		return false
+	case errors.Is(err, ErrReadOnly),
+		errors.Is(err, ErrDisabledInSafeMode),
+		errors.Is(err, ErrGeneratedByNotAllowed),
+		errors.Is(err, ErrStudentNotFound),
+		errors.Is(err, pdf.ErrPageLimitExceeded),
+		errors.Is(err, pdf.ErrFileTooLarge),
+		errors.Is(err, pdf.ErrReportExists):
+		return false
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return false
+	}
+
+	var clientErr *client.ClientError
+	if errors.As(err, &clientErr) {
+		return !isPermanentStatus(clientErr.StatusCode)
+	}
+	return true
+}
+
+// isPermanentStatus reports whether a Node.js API status code will be
+// returned again for the same request. Timeouts and rate limits are 4xx
+// codes that clear up on their own.
+func isPermanentStatus(status int) bool {
+	switch status {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	}
+	return status >= 400 && status < 500
+}
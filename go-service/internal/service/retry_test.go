@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"student-report-service/internal/client"
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+	"student-report-service/internal/pdf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportService_PipelineRetry(t *testing.T) {
+	student := &models.Student{ID: 1, Name: "John Doe"}
+	transient := errors.New("failed to save PDF: disk I/O error")
+
+	tests := []struct {
+		name             string
+		retryAttempts    int
+		fetchErr         error
+		renderErrs       []error
+		expectedAttempts int
+		expectedError    string
+	}{
+		{
+			name:             "Retries disabled",
+			retryAttempts:    0,
+			renderErrs:       []error{transient},
+			expectedAttempts: 1,
+			expectedError:    "failed to generate PDF report: failed to save PDF: disk I/O error",
+		},
+		{
+			name:             "Succeeds after transient failures",
+			retryAttempts:    3,
+			renderErrs:       []error{transient, transient},
+			expectedAttempts: 3,
+		},
+		{
+			name:             "Gives up after the last retry",
+			retryAttempts:    2,
+			renderErrs:       []error{transient, transient, transient},
+			expectedAttempts: 3,
+			expectedError:    "report generation failed after 3 attempts",
+		},
+		{
+			name:             "Student not found is not retried",
+			retryAttempts:    3,
+			fetchErr:         &client.ClientError{StatusCode: 404, Message: "Student not found"},
+			expectedAttempts: 1,
+			expectedError:    "failed to fetch student data",
+		},
+		{
+			name:             "Server errors are retried",
+			retryAttempts:    2,
+			fetchErr:         &client.ClientError{StatusCode: 503, Message: "Service unavailable"},
+			expectedAttempts: 3,
+			expectedError:    "report generation failed after 3 attempts",
+		},
+		{
+			name:             "Rate limits are retried",
+			retryAttempts:    1,
+			fetchErr:         fmt.Errorf("login failed: %w", &client.ClientError{StatusCode: 429, Message: "Too many requests"}),
+			expectedAttempts: 2,
+			expectedError:    "report generation failed after 2 attempts",
+		},
+		{
+			name:             "Incomplete responses are left to the client",
+			retryAttempts:    3,
+			fetchErr:         fmt.Errorf("%w: unexpected EOF", client.ErrIncompleteResponse),
+			expectedAttempts: 1,
+			expectedError:    "incomplete response from Node.js API",
+		},
+		{
+			name:             "Page limit is not retried",
+			retryAttempts:    3,
+			renderErrs:       []error{fmt.Errorf("%w (50 pages)", pdf.ErrPageLimitExceeded)},
+			expectedAttempts: 1,
+			expectedError:    "report exceeds maximum page count",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNodeClient := new(MockNodeJSClient)
+			mockPDFGen := new(MockPDFGenerator)
+
+			if tt.fetchErr != nil {
+				mockNodeClient.On("GetStudentByID", 1).Return(nil, tt.fetchErr)
+			} else {
+				mockNodeClient.On("GetStudentByID", 1).Return(student, nil)
+			}
+			for _, err := range tt.renderErrs {
+				mockPDFGen.On("GenerateStudentReport", student, mock.AnythingOfType("*models.ReportMetadata")).Return("", err).Once()
+			}
+			mockPDFGen.On("GenerateStudentReport", student, mock.AnythingOfType("*models.ReportMetadata")).Return("/path/to/report.pdf", nil)
+
+			cfg := &config.Config{Report: config.ReportConfig{RetryAttempts: tt.retryAttempts, RetryBackoff: time.Millisecond}}
+			service := NewReportService(mockNodeClient, mockPDFGen, cfg)
+
+			result, err := service.GenerateStudentReport(1, "Test User")
+
+			mockNodeClient.AssertNumberOfCalls(t, "GetStudentByID", tt.expectedAttempts)
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				var pipelineErr *PipelineError
+				if errors.As(err, &pipelineErr) {
+					assert.Equal(t, tt.expectedAttempts, pipelineErr.Attempts)
+				} else {
+					assert.Equal(t, 1, tt.expectedAttempts)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAttempts, result.Attempts)
+		})
+	}
+}
+
+func TestReportService_PipelineRetry_NoStudentData(t *testing.T) {
+	mockNodeClient := new(MockNodeJSClient)
+	mockNodeClient.On("GetStudentByID", 1).Return(nil, nil)
+
+	cfg := &config.Config{Report: config.ReportConfig{RetryAttempts: 3, RetryBackoff: time.Millisecond}}
+	_, err := NewReportService(mockNodeClient, new(MockPDFGenerator), cfg).GenerateStudentReport(1, "Test User")
+
+	assert.ErrorIs(t, err, ErrStudentNotFound)
+	mockNodeClient.AssertNumberOfCalls(t, "GetStudentByID", 1)
+}
+
+func TestReportService_PipelineRetry_Cancelled(t *testing.T) {
+	mockNodeClient := new(MockNodeJSClient)
+	mockNodeClient.On("GetStudentByID", 1).Return(nil, errors.New("connection reset by peer"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cfg := &config.Config{Report: config.ReportConfig{RetryAttempts: 3, RetryBackoff: time.Hour}}
+	_, err := NewReportService(mockNodeClient, new(MockPDFGenerator), cfg).GenerateStudentReportWithProfile(ctx, 1, ReportOptions{GeneratedBy: "Test User"})
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Contains(t, err.Error(), "connection reset by peer")
+	var pipelineErr *PipelineError
+	require.ErrorAs(t, err, &pipelineErr)
+	assert.Equal(t, 1, pipelineErr.Attempts)
+	mockNodeClient.AssertNumberOfCalls(t, "GetStudentByID", 1)
+}